@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/danjdewhurst/go-toc/internal/mcp"
+)
+
+// mcpCmd serves the docs index to MCP clients over stdio.
+var mcpCmd = &cobra.Command{
+	Use:   "mcp [directory]",
+	Short: "Serve the docs index to MCP clients over stdio",
+	Long: `mcp starts a Model Context Protocol server on stdin/stdout so coding
+agents can navigate documentation on demand. It exposes the tools
+list_docs, get_toc, read_doc and search_docs, and never opens a
+network connection.
+
+Example:
+  go-toc mcp ./docs --gitignore`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	absPath, err := resolveDirectory(args)
+	if err != nil {
+		return err
+	}
+
+	scannerConfig, err := newScannerConfig(absPath)
+	if err != nil {
+		return err
+	}
+
+	server := mcp.NewServer(mcp.Config{
+		Scanner:      scannerConfig,
+		Title:        title,
+		SummaryChars: summaryChars,
+		Version:      Version,
+	})
+
+	return server.Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+}
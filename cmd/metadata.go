@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/danjdewhurst/go-toc/internal/gitinfo"
+	"github.com/danjdewhurst/go-toc/internal/keywords"
+	"github.com/danjdewhurst/go-toc/internal/parser"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+	"github.com/danjdewhurst/go-toc/internal/worker"
+)
+
+// loadDocuments parses every markdown file, keyed by relative path.
+// Files that cannot be parsed are left out.
+func loadDocuments(relPaths []string, rootPath string, sequential bool) map[string]*parser.Document {
+	docs := make(map[string]*parser.Document, len(relPaths))
+	if len(relPaths) == 0 {
+		return docs
+	}
+
+	jobs := make([]worker.Job, len(relPaths))
+	for i, relPath := range relPaths {
+		jobs[i] = worker.Job{
+			FilePath: relPath,
+			Data:     filepath.Join(rootPath, relPath),
+		}
+	}
+
+	processFunc := func(job worker.Job) worker.Result {
+		absPath, ok := job.Data.(string)
+		if !ok {
+			return worker.Result{
+				FilePath: job.FilePath,
+				Error:    fmt.Errorf("invalid job data type"),
+			}
+		}
+		doc, err := parser.ParseDocument(absPath)
+		return worker.Result{
+			FilePath: job.FilePath,
+			Data:     doc,
+			Error:    err,
+		}
+	}
+
+	var results map[string]worker.Result
+	if sequential {
+		results = worker.ProcessSequential(jobs, processFunc)
+	} else {
+		numWorkers := min(runtime.NumCPU(), len(relPaths))
+		results = worker.ProcessAll(jobs, numWorkers, processFunc)
+	}
+
+	for relPath, result := range results {
+		if doc, ok := result.Data.(*parser.Document); ok && result.Error == nil {
+			docs[relPath] = doc
+		}
+	}
+
+	return docs
+}
+
+// annotateTree sets each file node's title, ID, frontmatter tags and
+// status, plus the top keywords computed across the whole set of documents
+// when keywordCount is positive.
+func annotateTree(tree *toc.Tree, docs map[string]*parser.Document, keywordCount int) {
+	var corpus *keywords.Corpus
+	if keywordCount > 0 {
+		corpus = keywords.NewCorpus()
+		for relPath, doc := range docs {
+			corpus.Add(relPath, doc)
+		}
+	}
+
+	for relPath, doc := range docs {
+		node := tree.Find(relPath)
+		if node == nil {
+			continue
+		}
+
+		node.Title = doc.Title()
+		node.ID = doc.Frontmatter.String("id")
+		node.Tags = doc.Frontmatter.Strings("tags")
+		node.Weight = documentWeight(doc)
+		node.Status = documentStatus(doc)
+		if corpus != nil {
+			node.Keywords = corpus.Keywords(relPath, keywordCount)
+		}
+	}
+}
+
+// annotateHistory sets the git history of each file node.
+func annotateHistory(tree *toc.Tree, history map[string]gitinfo.File) {
+	for relPath, file := range history {
+		node := tree.Find(relPath)
+		if node == nil || node.IsDir {
+			continue
+		}
+
+		node.LastCommit = file.LastCommit
+		node.LastAuthor = file.LastAuthor
+		node.Commits = file.Commits
+	}
+}
+
+// weightKeys are the frontmatter keys read as sort weight, in order.
+var weightKeys = []string{"weight", "order"}
+
+// documentWeight returns the sort weight declared in frontmatter, or 0.
+func documentWeight(doc *parser.Document) int {
+	for _, key := range weightKeys {
+		if weight, ok := doc.Frontmatter.Int(key); ok {
+			return weight
+		}
+	}
+	return 0
+}
+
+// documentStatus returns the status declared in frontmatter, either as a
+// status key or as a draft or deprecated flag, or "".
+func documentStatus(doc *parser.Document) string {
+	if status := doc.Frontmatter.String("status"); status != "" {
+		return strings.ToLower(status)
+	}
+	for _, flag := range []string{"draft", "deprecated"} {
+		if doc.Frontmatter.Bool(flag) {
+			return flag
+		}
+	}
+	return ""
+}
+
+// groupKeys maps --group-by shorthands to the frontmatter keys they read.
+var groupKeys = map[string][]string{
+	"tag":      {"tags"},
+	"tags":     {"tags"},
+	"category": {"categories", "category"},
+}
+
+// groupTree regroups the tree by the values of a frontmatter key.
+func groupTree(tree *toc.Tree, docs map[string]*parser.Document, groupBy string) *toc.Tree {
+	keys, ok := groupKeys[groupBy]
+	if !ok {
+		keys = []string{groupBy}
+	}
+
+	return toc.GroupBy(tree, func(node *toc.Node) []string {
+		doc, ok := docs[node.Path]
+		if !ok {
+			return nil
+		}
+		var values []string
+		for _, key := range keys {
+			values = append(values, doc.Frontmatter.Strings(key)...)
+		}
+		return values
+	})
+}
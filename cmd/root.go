@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
+	"github.com/danjdewhurst/go-toc/internal/gitinfo"
 	"github.com/danjdewhurst/go-toc/internal/parser"
 	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/terminal"
 	"github.com/danjdewhurst/go-toc/internal/toc"
 	"github.com/danjdewhurst/go-toc/internal/worker"
 )
@@ -18,15 +23,42 @@ import (
 var Version = "dev"
 
 var (
-	ignorePatterns []string
-	useGitignore   bool
-	maxDepth       int
-	includeSummary bool
-	summaryChars   int
-	singleThreaded bool
-	outputFile     string
-	title          string
-	fancy          bool
+	ignorePatterns  []string
+	includePatterns []string
+	includeHidden   bool
+	allowHidden     []string
+	hiddenPresets   []string
+	followSymlinks  bool
+	followExternal  bool
+	useGitignore    bool
+	maxDepth        int
+	includeSummary  bool
+	summaryChars    int
+	summaryMode     string
+	singleThreaded  bool
+	outputFile      string
+	title           string
+	fancy           bool
+	outputFormat    string
+	includeTags     bool
+	keywordCount    int
+	groupBy         string
+	sortOrder       string
+	promoteIndex    bool
+	compact         bool
+	maxPerDir       int
+	openDepth       int
+	templateFile    string
+	iconTheme       string
+	iconsFile       string
+	showBadges      bool
+	staleAfter      string
+	excludeDrafts   bool
+	gitInfo         bool
+	recentCount     int
+	changedSince    string
+	gitTracked      bool
+	gitUntracked    bool
 )
 
 // rootCmd represents the base command.
@@ -39,7 +71,18 @@ a table of contents in a tree structure format.
 Example:
   go-toc .
   go-toc ./docs --summary --max-depth 3
-  go-toc . --ignore "vendor/*" --gitignore`,
+  go-toc . --ignore "vendor/*" --gitignore
+  go-toc ./docs --tags --format json
+  go-toc ./docs --group-by tag
+  go-toc ./tutorials --sort natural
+  go-toc ./src --compact
+  go-toc ./docs --max-per-dir 10
+  go-toc . --format details --open-depth 1
+  go-toc ./docs --summary --format html -o index.html
+  go-toc ./docs --format mkdocs --promote-index
+  go-toc ./docs --summary --template index.tmpl
+  go-toc mcp ./docs
+  go-toc serve ./docs`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runToc,
 }
@@ -52,49 +95,103 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().StringArrayVarP(&ignorePatterns, "ignore", "i", []string{}, "glob patterns to ignore (can be specified multiple times)")
-	rootCmd.Flags().BoolVarP(&useGitignore, "gitignore", "g", false, "include .gitignore patterns")
-	rootCmd.Flags().IntVarP(&maxDepth, "max-depth", "d", 0, "maximum recursion depth (0 = unlimited)")
+	// Scanning flags are shared with subcommands
+	rootCmd.PersistentFlags().StringArrayVarP(&ignorePatterns, "ignore", "i", []string{}, "glob patterns to ignore (can be specified multiple times)")
+	rootCmd.PersistentFlags().StringArrayVar(&includePatterns, "include", []string{}, "gitignore-style patterns of files to include, \"!\" to exclude again (can be specified multiple times)")
+	rootCmd.PersistentFlags().BoolVar(&includeHidden, "hidden", false, "include hidden files and directories (except .git)")
+	rootCmd.PersistentFlags().StringArrayVar(&allowHidden, "allow-hidden", []string{}, "hidden directories or files to include without --hidden (can be specified multiple times)")
+	rootCmd.PersistentFlags().StringArrayVar(&hiddenPresets, "hidden-preset", []string{}, "named sets of hidden directories to include: github (.github)")
+	rootCmd.PersistentFlags().BoolVar(&followSymlinks, "follow-symlinks", false, "descend into symlinked directories inside the root")
+	rootCmd.PersistentFlags().BoolVar(&followExternal, "follow-external", false, "follow symlinks pointing outside the root too (implies --follow-symlinks)")
+	rootCmd.PersistentFlags().BoolVarP(&useGitignore, "gitignore", "g", false, "include .gitignore patterns")
+	rootCmd.PersistentFlags().IntVarP(&maxDepth, "max-depth", "d", 0, "maximum recursion depth (0 = unlimited)")
+	rootCmd.PersistentFlags().BoolVar(&gitTracked, "git-tracked", false, "only include files committed to git, using git's own ignore rules instead of --gitignore")
+	rootCmd.PersistentFlags().BoolVar(&gitUntracked, "untracked", false, "with --git-tracked, also include untracked files that are not ignored")
+	rootCmd.PersistentFlags().StringVar(&changedSince, "changed-since", "", "only include markdown files added, modified or deleted since this git revision")
+	rootCmd.PersistentFlags().StringVarP(&title, "title", "t", "Table of Contents", "title for the table of contents")
+	rootCmd.PersistentFlags().IntVarP(&summaryChars, "summary-chars", "c", 100, "maximum characters for summary")
+
 	rootCmd.Flags().BoolVarP(&includeSummary, "summary", "s", false, "include first paragraph summary for each file")
-	rootCmd.Flags().IntVarP(&summaryChars, "summary-chars", "c", 100, "maximum characters for summary")
+	rootCmd.Flags().StringVar(&summaryMode, "summary-mode", "paragraph", "summary strategy: paragraph, description, sentence or textrank")
 	rootCmd.Flags().BoolVar(&singleThreaded, "single-threaded", false, "disable concurrent processing")
 	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file (default: stdout)")
-	rootCmd.Flags().StringVarP(&title, "title", "t", "Table of Contents", "title for the table of contents")
 	rootCmd.Flags().BoolVarP(&fancy, "fancy", "f", false, "use emoji icons instead of ASCII tree")
+	rootCmd.Flags().StringVar(&outputFormat, "format", "", "output format: markdown, json, details, html, mkdocs, docusaurus, mdbook, rst, asciidoc or term (default: term on a terminal, otherwise markdown)")
+	rootCmd.Flags().StringVar(&templateFile, "template", "", "render with a text/template file instead of --format")
+	rootCmd.Flags().BoolVar(&includeTags, "tags", false, "include frontmatter tags and extracted keywords for each file")
+	rootCmd.Flags().IntVar(&keywordCount, "keywords", 5, "number of keywords to extract per file with --tags (0 = frontmatter tags only)")
+	rootCmd.Flags().StringVar(&groupBy, "group-by", "", "group files by frontmatter tag, category or any other key instead of directory")
+	rootCmd.Flags().StringVar(&sortOrder, "sort", "name", "sort order: name, natural, weight, mtime, title or updated")
+	rootCmd.Flags().BoolVar(&promoteIndex, "promote-index", false, "link directories to their README.md or index.md instead of listing it as a child")
+	rootCmd.Flags().BoolVar(&compact, "compact", false, "merge chains of single-child directories into one entry (e.g. a/b/c/)")
+	rootCmd.Flags().IntVar(&maxPerDir, "max-per-dir", 0, "maximum entries listed per directory, followed by an \"… and N more\" link (0 = unlimited)")
+	rootCmd.Flags().StringVar(&iconTheme, "icon-theme", toc.DefaultIconTheme, "icon theme for --fancy: emoji, nerd or text")
+	rootCmd.Flags().StringVar(&iconsFile, "icons", "", "JSON file mapping extensions, file names, statuses and directories to icons for --fancy")
+	rootCmd.Flags().BoolVar(&showBadges, "badges", false, "show DRAFT, DEPRECATED and \"stale 14mo\" badges after entries")
+	rootCmd.Flags().StringVar(&staleAfter, "stale-after", "12mo", "age after which --badges marks a document as stale, e.g. 90d, 6w, 12mo or 2y (0 = never)")
+	rootCmd.Flags().BoolVar(&excludeDrafts, "exclude-drafts", false, "leave out documents marked as drafts in frontmatter")
+	rootCmd.Flags().BoolVar(&gitInfo, "git-info", false, "attach last commit date, author and commit count from the local git history")
+	rootCmd.Flags().IntVar(&recentCount, "recent", 0, "list the N most recently updated files in a separate section")
+	rootCmd.Flags().IntVar(&openDepth, "open-depth", 0, "directory levels expanded by default in the details and html formats (0 = all collapsed)")
 
 	rootCmd.Version = Version
 }
 
 func runToc(cmd *cobra.Command, args []string) error {
-	// Determine target directory
-	targetDir := "."
-	if len(args) > 0 {
-		targetDir = args[0]
+	absPath, err := resolveDirectory(args)
+	if err != nil {
+		return err
 	}
 
-	// Resolve to absolute path
-	absPath, err := filepath.Abs(targetDir)
+	mode, err := parser.ParseSummaryMode(summaryMode)
+	if err != nil {
+		return err
+	}
+	format, err := toc.ParseFormat(outputFormat)
 	if err != nil {
-		return fmt.Errorf("failed to resolve path: %w", err)
+		return err
+	}
+	order, err := toc.ParseSortOrder(sortOrder)
+	if err != nil {
+		return err
+	}
+	staleAge, err := toc.ParseAge(staleAfter)
+	if err != nil {
+		return err
 	}
 
-	// Verify directory exists
-	info, err := os.Stat(absPath)
+	// Parse the template up front so mistakes are reported before scanning
+	var tmpl *template.Template
+	if templateFile != "" {
+		if tmpl, err = toc.ParseTemplateFile(templateFile); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	// Load icons up front for the same reason
+	var icons *toc.IconSet
+	if iconsFile != "" {
+		icons, err = toc.LoadIconSet(iconsFile, iconTheme)
+	} else {
+		icons, err = toc.IconTheme(iconTheme)
+	}
 	if err != nil {
-		return fmt.Errorf("cannot access directory: %w", err)
+		return fmt.Errorf("invalid icons: %w", err)
 	}
-	if !info.IsDir() {
-		return fmt.Errorf("%s is not a directory", targetDir)
+
+	// Markdown is unreadable in a terminal, so interactive use gets the
+	// term format unless another format or an output file was chosen
+	out := cmd.OutOrStdout()
+	interactive := outputFile == "" && terminal.IsTerminal(out)
+	if interactive && tmpl == nil && !cmd.Flags().Changed("format") && !cmd.Flags().Changed("fancy") {
+		format = toc.FormatTerm
 	}
 
 	// Create scanner
-	scannerConfig := scanner.Config{
-		RootPath:       absPath,
-		IgnorePatterns: ignorePatterns,
-		UseGitignore:   useGitignore,
-		MaxDepth:       maxDepth,
+	scannerConfig, err := newScannerConfig(absPath)
+	if err != nil {
+		return err
 	}
-
 	s := scanner.New(scannerConfig)
 
 	// Scan directory (single walk gets both tree and files)
@@ -104,7 +201,7 @@ func runToc(cmd *cobra.Command, args []string) error {
 	}
 	tree := result.Tree
 
-	// Log any gitignore parsing errors
+	// Log any gitignore and tocignore parsing errors
 	for _, gitErr := range result.GitignoreErrors {
 		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to parse %s: %v\n", gitErr.Path, gitErr.Err)
 	}
@@ -112,19 +209,87 @@ func runToc(cmd *cobra.Command, args []string) error {
 	// Extract summaries if requested
 	summaries := make(map[string]string)
 	if includeSummary {
-		summaries = extractSummaries(result.Files, result.RootPath, summaryChars, singleThreaded)
+		summaries = extractSummaries(result.Files, result.RootPath, summaryChars, mode, singleThreaded)
 	}
 
+	// Git history replaces meaningless checkout mtimes where available;
+	// only an explicit --git-info requires it, otherwise modification
+	// times are used when git is missing or cannot read the repository
+	if gitInfo || order == toc.SortUpdated || recentCount > 0 || (showBadges && staleAge > 0) {
+		history, err := gitinfo.History(absPath)
+		switch {
+		case err == nil:
+			annotateHistory(tree, history)
+		case gitInfo:
+			return fmt.Errorf("failed to read git history: %w", err)
+		case !errors.Is(err, gitinfo.ErrNotRepository):
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: using modification times, failed to read git history: %v\n", err)
+		}
+	}
+
+	// Attach document metadata when a feature needs it
+	var docs map[string]*parser.Document
+	needDocs := includeTags || groupBy != "" || order == toc.SortWeight || order == toc.SortTitle ||
+		format.UsesTitles() || tmpl != nil || fancy || showBadges || excludeDrafts
+	if needDocs {
+		docs = loadDocuments(result.Files, result.RootPath, singleThreaded)
+
+		keywordsWanted := 0
+		if includeTags {
+			keywordsWanted = keywordCount
+		}
+		annotateTree(tree, docs, keywordsWanted)
+	}
+	if excludeDrafts {
+		tree.Exclude(toc.IsDraft)
+	}
+
+	// The scanner sorts by name; re-sort for other orders before grouping
+	// so files keep that order within each group
+	if order != toc.SortName {
+		tree.SortBy(order)
+	}
+	if groupBy != "" {
+		tree = groupTree(tree, docs, groupBy)
+	}
+	if promoteIndex {
+		tree.PromoteIndex(toc.DefaultIndexNames)
+	}
+	if compact {
+		tree.Compact()
+	}
+	tree.LimitChildren(maxPerDir)
+
 	// Generate ToC
 	genConfig := toc.GeneratorConfig{
 		Title:          title,
+		Format:         format,
 		IncludeSummary: includeSummary,
 		Summaries:      summaries,
+		IncludeTags:    includeTags,
 		Fancy:          fancy,
+		Icons:          icons,
+		Badges:         showBadges,
+		StaleAfter:     staleAge,
+		Recent:         recentCount,
+		OpenDepth:      openDepth,
+		NoColor:        !interactive || !terminal.ColorEnabled(),
+	}
+	if interactive {
+		// Escape sequences would end up verbatim in pipes and files
+		genConfig.Width = terminal.Width(out)
+		genConfig.RootPath = absPath
 	}
 
 	gen := toc.NewGenerator(genConfig)
-	output := gen.Generate(tree)
+	var output string
+	if tmpl != nil {
+		if output, err = gen.GenerateTemplate(tree, tmpl); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+	} else {
+		output = gen.Generate(tree)
+	}
 
 	// Write output
 	if outputFile != "" {
@@ -133,13 +298,63 @@ func runToc(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Fprintf(cmd.ErrOrStderr(), "ToC written to %s\n", outputFile)
 	} else {
-		fmt.Fprint(cmd.OutOrStdout(), output)
+		fmt.Fprint(out, output)
 	}
 
 	return nil
 }
 
-func extractSummaries(relPaths []string, rootPath string, maxChars int, sequential bool) map[string]string {
+// resolveDirectory returns the absolute path of the target directory
+// given on the command line, defaulting to the current directory.
+func resolveDirectory(args []string) (string, error) {
+	// Determine target directory
+	targetDir := "."
+	if len(args) > 0 {
+		targetDir = args[0]
+	}
+
+	// Resolve to absolute path
+	absPath, err := filepath.Abs(targetDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	// Verify directory exists
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot access directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", targetDir)
+	}
+
+	return absPath, nil
+}
+
+// newScannerConfig builds the scanner configuration from the shared flags.
+func newScannerConfig(absPath string) (scanner.Config, error) {
+	presetHidden, err := scanner.ExpandHiddenPresets(hiddenPresets)
+	if err != nil {
+		return scanner.Config{}, err
+	}
+
+	return scanner.Config{
+		RootPath:       absPath,
+		IgnorePatterns: ignorePatterns,
+		Include:        includePatterns,
+		UseGitignore:   useGitignore,
+		MaxDepth:       maxDepth,
+		Hidden:         includeHidden,
+		AllowHidden:    append(slices.Clone(allowHidden), presetHidden...),
+		FollowSymlinks: followSymlinks,
+		FollowExternal: followExternal,
+		ChangedSince:   changedSince,
+		GitTracked:     gitTracked,
+		Untracked:      gitUntracked,
+	}, nil
+}
+
+func extractSummaries(relPaths []string, rootPath string, maxChars int, mode parser.SummaryMode, sequential bool) map[string]string {
 	if len(relPaths) == 0 {
 		return make(map[string]string)
 	}
@@ -148,6 +363,7 @@ func extractSummaries(relPaths []string, rootPath string, maxChars int, sequenti
 	// Store absolute path in Data for file reading
 	type jobData struct {
 		maxChars int
+		mode     parser.SummaryMode
 		absPath  string
 	}
 
@@ -155,7 +371,7 @@ func extractSummaries(relPaths []string, rootPath string, maxChars int, sequenti
 	for i, relPath := range relPaths {
 		jobs[i] = worker.Job{
 			FilePath: relPath, // Relative path used as key
-			Data:     jobData{maxChars: maxChars, absPath: filepath.Join(rootPath, relPath)},
+			Data:     jobData{maxChars: maxChars, mode: mode, absPath: filepath.Join(rootPath, relPath)},
 		}
 	}
 
@@ -168,7 +384,7 @@ func extractSummaries(relPaths []string, rootPath string, maxChars int, sequenti
 				Error:    fmt.Errorf("invalid job data type"),
 			}
 		}
-		summary, err := parser.ExtractSummary(data.absPath, data.maxChars)
+		summary, err := parser.ExtractSummaryWithMode(data.absPath, data.maxChars, data.mode)
 		return worker.Result{
 			FilePath: job.FilePath, // Return relative path as key
 			Summary:  summary,
@@ -6,6 +6,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/testutil"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -49,6 +52,82 @@ func TestRootCommand(t *testing.T) {
 			wantErr:     false,
 			wantContain: []string{"README.md"},
 		},
+		{
+			name:        "summary mode sentence",
+			args:        []string{tmpDir, "--summary", "--summary-mode", "sentence"},
+			wantErr:     false,
+			wantContain: []string{"Getting started guide for new users."},
+		},
+		{
+			name:        "tags",
+			args:        []string{tmpDir, "--tags", "--keywords", "2"},
+			wantErr:     false,
+			wantContain: []string{"> Tags: handlers, api", "> Tags: guide, getting"},
+		},
+		{
+			name:        "json format",
+			args:        []string{tmpDir, "--format", "json", "--summary"},
+			wantErr:     false,
+			wantContain: []string{`"path": "docs/guide.md"`, `"summary": "Getting started guide for new users."`},
+		},
+		{
+			name:        "details format",
+			args:        []string{tmpDir, "--format", "details", "--open-depth", "1"},
+			wantErr:     false,
+			wantContain: []string{"- <details open>\n  <summary>docs/</summary>", "  - <details>\n    <summary>api/</summary>"},
+		},
+		{
+			name:        "html format",
+			args:        []string{tmpDir, "--format", "html", "--summary"},
+			wantErr:     false,
+			wantContain: []string{"<!DOCTYPE html>", `<a href="docs/guide.md">guide.md</a>`, "Getting started guide for new users."},
+		},
+		{
+			name:        "mkdocs format",
+			args:        []string{tmpDir, "--format", "mkdocs"},
+			wantErr:     false,
+			wantContain: []string{"nav:\n  - docs:\n      - api:\n          - Handlers: docs/api/handlers.md\n", "      - Guide: docs/guide.md\n"},
+		},
+		{
+			name:        "mdbook format",
+			args:        []string{tmpDir, "--format", "mdbook"},
+			wantErr:     false,
+			wantContain: []string{"- [docs]()\n", "    - [Guide](docs/guide.md)\n", "- [README](README.md)\n"},
+		},
+		{
+			name:        "rst format",
+			args:        []string{tmpDir, "--format", "rst"},
+			wantErr:     false,
+			wantContain: []string{".. toctree::\n   :hidden:\n\n   docs/api/handlers\n", "- **docs/**\n", "- :doc:`README.md <README>`\n"},
+		},
+		{
+			name:        "asciidoc format",
+			args:        []string{tmpDir, "--format", "asciidoc"},
+			wantErr:     false,
+			wantContain: []string{"= Table of Contents\n", "*** xref:docs/api/handlers.adoc[handlers.md]\n"},
+		},
+		{
+			name:        "term format",
+			args:        []string{tmpDir, "--format", "term", "--summary"},
+			wantErr:     false,
+			wantContain: []string{"└── ", "This is the main readme file for the project.", "2 directories, 3 files"},
+		},
+		{
+			name:    "invalid format",
+			args:    []string{tmpDir, "--format", "yaml"},
+			wantErr: true,
+		},
+		{
+			name:        "promote index",
+			args:        []string{tmpDir, "--promote-index"},
+			wantErr:     false,
+			wantContain: []string{"README.md", "guide.md"},
+		},
+		{
+			name:    "invalid summary mode",
+			args:    []string{tmpDir, "--summary-mode", "bogus"},
+			wantErr: true,
+		},
 		{
 			name:    "invalid directory",
 			args:    []string{"/nonexistent/path"},
@@ -240,27 +319,169 @@ func TestDefaultDirectory(t *testing.T) {
 	}
 }
 
-// Helper functions
+func TestMCPCommandTitle(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
 
-func setupTestDir(t *testing.T) string {
-	t.Helper()
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_toc"}}` + "\n"))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{"mcp", tmpDir, "--title", "Team Docs"})
+	defer rootCmd.SetIn(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "# Team Docs") {
+		t.Errorf("expected rendered TOC to use --title, got:\n%s", output)
+	}
+}
+
+func TestMCPCommand(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_docs"}}` + "\n"))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{"mcp", tmpDir})
+	defer rootCmd.SetIn(nil)
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{`"jsonrpc":"2.0"`, "docs/guide.md", "Getting started guide"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestSearchCommand(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	indexPath := filepath.Join(tmpDir, "index", "search.idx")
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{"search", "getting started", tmpDir, "--index", indexPath})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(stdout.String(), "docs/guide.md:3: Getting started guide for new users.") {
+		t.Errorf("expected ranked path:line result, got:\n%s", stdout.String())
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Errorf("index should be persisted: %v", err)
+	}
+}
+
+func TestServeCommandErrors(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "invalid directory",
+			args:    []string{"serve", "/nonexistent/path"},
+			wantErr: "cannot access directory",
+		},
+		{
+			name:    "invalid address",
+			args:    []string{"serve", tmpDir, "--addr", "localhost:99999"},
+			wantErr: "failed to listen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			var stdout bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stdout)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGroupByFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"ops/deploy.md":  "---\ncategories:\n  - runbooks\n---\n\n# Deploy",
+		"ops/restore.md": "---\ncategory: runbooks\ntags: [backup]\n---\n\n# Restore",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--group-by", "category"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	runbooks := strings.Index(output, "runbooks/")
+	untagged := strings.Index(output, "untagged/")
+	if runbooks == -1 || untagged == -1 || runbooks > untagged {
+		t.Fatalf("expected runbooks group before untagged group, got:\n%s", output)
+	}
+	for _, want := range []string{"[ops/deploy.md](ops/deploy.md)", "[ops/restore.md](ops/restore.md)"} {
+		if idx := strings.Index(output, want); idx < runbooks || idx > untagged {
+			t.Errorf("%s should be listed under runbooks, got:\n%s", want, output)
+		}
+	}
+	if !strings.Contains(output[untagged:], "[README.md](README.md)") {
+		t.Errorf("untagged files should be listed under untagged, got:\n%s", output)
+	}
+}
 
+func TestDocusaurusDocIDs(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "go-toc-cmd-test")
 	if err != nil {
 		t.Fatal(err)
 	}
+	defer os.RemoveAll(tmpDir)
 
-	// Create test structure
 	files := map[string]string{
-		"README.md":          "# README\n\nThis is the main readme file for the project.",
-		"docs/guide.md":      "# Guide\n\nGetting started guide for new users.",
-		"docs/api/handlers.md": "# Handlers\n\nAPI handler documentation.",
+		"01-tutorial/02-setup.md":  "# Setup",
+		"01-tutorial/10-deploy.md": "---\nid: deploying\n---\n\n# Deploy",
 	}
-
 	for path, content := range files {
 		fullPath := filepath.Join(tmpDir, path)
-		dir := filepath.Dir(fullPath)
-		if err := os.MkdirAll(dir, 0755); err != nil {
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
 			t.Fatal(err)
 		}
 		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
@@ -268,18 +489,690 @@ func setupTestDir(t *testing.T) string {
 		}
 	}
 
-	return tmpDir
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--format", "docusaurus"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := stdout.String()
+	for _, want := range []string{`"id": "tutorial/setup"`, `"id": "tutorial/deploying"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s in sidebar, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestTermFormatNotInteractive(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	outputPath := filepath.Join(tmpDir, "toc.txt")
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--format", "term", "-o", outputPath})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "README.md") || strings.Contains(string(data), "\x1b") {
+		t.Errorf("expected plain term output in a file, got:\n%q", data)
+	}
+
+	// A buffer stands in for a pipe
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--format", "term"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); strings.Contains(output, "\x1b") {
+		t.Errorf("expected no escape sequences when piped, got:\n%q", output)
+	}
+}
+
+func TestFormatUsage(t *testing.T) {
+	usage := rootCmd.Flags().FlagUsages()
+	line := ""
+	for _, l := range strings.Split(usage, "\n") {
+		if strings.Contains(l, "--format string") {
+			line = l
+		}
+	}
+	if strings.Count(line, "default") != 1 {
+		t.Errorf("expected a single default in the --format usage, got %q", line)
+	}
+}
+
+func TestBadgesWithoutGit(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Stop git from finding a repository above the temp directory, in case
+	// it is installed somewhere PATH still reaches
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+	t.Setenv("PATH", filepath.Join(tmpDir, "no-such-bin"))
+
+	resetFlags()
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{tmpDir, "--badges", "--sort", "updated"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("expected modification times to be used without git, got error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "[guide.md](docs/guide.md)") {
+		t.Errorf("expected the TOC, got:\n%s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Warning: using modification times") {
+		t.Errorf("expected a warning about git history, got:\n%s", stderr.String())
+	}
+
+	// An explicit --git-info still needs git
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--git-info"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected error for --git-info without git")
+	}
+}
+
+func TestSortFlag(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-cmd-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files := map[string]string{
+		"2-setup.md":   "---\nweight: 2\n---\n# Zebra Setup",
+		"10-deploy.md": "---\nweight: 1\n---\n# Alpha Deploy",
+		"intro.md":     "# Middle Intro",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		order    string
+		expected []string
+	}{
+		{"name", []string{"10-deploy.md", "2-setup.md", "intro.md"}},
+		{"natural", []string{"2-setup.md", "10-deploy.md", "intro.md"}},
+		{"weight", []string{"10-deploy.md", "2-setup.md", "intro.md"}},
+		{"title", []string{"10-deploy.md", "intro.md", "2-setup.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			resetFlags()
+			var stdout bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stdout)
+			rootCmd.SetArgs([]string{tmpDir, "--sort", tt.order})
+
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			output := stdout.String()
+			last := -1
+			for _, name := range tt.expected {
+				idx := strings.Index(output, "["+name+"]")
+				if idx < last {
+					t.Errorf("expected order %v, got:\n%s", tt.expected, output)
+					break
+				}
+				last = idx
+			}
+		})
+	}
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--sort", "random"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected error for unknown sort order")
+	}
+}
+
+func TestPromoteIndexFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "index.md"), []byte("# Docs\n\nEverything about the docs."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--promote-index", "--summary"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "[docs/](docs/index.md)") {
+		t.Errorf("docs directory should link to its index, got:\n%s", output)
+	}
+	if !strings.Contains(output, "> Everything about the docs.") {
+		t.Errorf("docs directory should use its index summary, got:\n%s", output)
+	}
+	if strings.Contains(output, "[index.md]") {
+		t.Errorf("index.md should not be listed again, got:\n%s", output)
+	}
+}
+
+func TestCompactFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	nested := filepath.Join(tmpDir, "src", "main", "java", "Overview.md")
+	if err := os.MkdirAll(filepath.Dir(nested), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(nested, []byte("# Overview"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--compact"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "src/main/java/  \n") {
+		t.Errorf("expected single-child chain to be merged, got:\n%s", output)
+	}
+	if !strings.Contains(output, "[Overview.md](src/main/java/Overview.md)") {
+		t.Errorf("expected file under merged directory, got:\n%s", output)
+	}
+	// docs has two children, so it keeps its own entry
+	if !strings.Contains(output, "docs/  \n") {
+		t.Errorf("directories with several children should not be merged, got:\n%s", output)
+	}
+}
+
+func TestMaxPerDirFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	for _, name := range []string{"0.1.0.md", "0.2.0.md", "0.3.0.md", "0.4.0.md"} {
+		path := filepath.Join(tmpDir, "changelog", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--max-per-dir", "2", "--sort", "natural"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"[0.1.0.md](changelog/0.1.0.md)", "[0.2.0.md](changelog/0.2.0.md)", "[… and 2 more](changelog/)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "0.3.0.md") {
+		t.Errorf("entries past the limit should be hidden, got:\n%s", output)
+	}
+}
+
+func TestTemplateFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	tmplPath := filepath.Join(tmpDir, "index.tmpl")
+	tmplText := `{{range flatten .Nodes}}{{if not .IsDir}}{{indent .Depth}}* {{.Title}}: {{.Link}} - {{.Summary}}
+{{end}}{{end}}`
+	if err := os.WriteFile(tmplPath, []byte(tmplText), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--summary", "--template", tmplPath})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "  * Guide: docs/guide.md - Getting started guide for new users.\n") {
+		t.Errorf("expected template output with titles and summaries, got:\n%s", output)
+	}
+
+	// Broken templates are reported before scanning
+	if err := os.WriteFile(tmplPath, []byte("{{range}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--template", tmplPath})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("expected invalid template error, got %v", err)
+	}
+}
+
+func TestIconFlags(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	draft := "---\ndraft: true\n---\n# Plans\n\nNot ready yet."
+	if err := os.WriteFile(filepath.Join(tmpDir, "plans.md"), []byte(draft), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--fancy", "--icon-theme", "text"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"[dir] **docs/**", "[readme] [README.md](README.md)", "[draft] [plans.md](plans.md)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+
+	// A config file overrides icons on top of its theme
+	iconsPath := filepath.Join(tmpDir, "icons.json")
+	if err := os.WriteFile(iconsPath, []byte(`{"theme": "text", "directories": {"docs": "[manual]"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--fancy", "--icons", iconsPath})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "[manual] **docs/**") {
+		t.Errorf("expected configured directory icon, got:\n%s", stdout.String())
+	}
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--fancy", "--icon-theme", "unknown"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown icon theme") {
+		t.Errorf("expected unknown icon theme error, got %v", err)
+	}
+}
+
+func TestBadgeFlags(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	draft := "---\ndraft: true\n---\n# Plans\n\nNot ready yet."
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "plans.md"), []byte(draft), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().AddDate(-1, -3, 0)
+	if err := os.Chtimes(filepath.Join(tmpDir, "docs", "guide.md"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--badges"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"[plans.md](docs/plans.md) `DRAFT`", "[guide.md](docs/guide.md) `stale 15mo`"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--badges", "--stale-after", "0", "--exclude-drafts"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output = stdout.String()
+	if strings.Contains(output, "plans.md") || strings.Contains(output, "stale") {
+		t.Errorf("expected drafts and stale badges to be left out, got:\n%s", output)
+	}
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--stale-after", "soon"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid age") {
+		t.Errorf("expected invalid age error, got %v", err)
+	}
+}
+
+func TestGitInfoFlags(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	testutil.GitAt(t, tmpDir, "Alice", jan, "init", "-q")
+	testutil.GitAt(t, tmpDir, "Alice", jan, "add", "README.md", "docs")
+	testutil.GitAt(t, tmpDir, "Alice", jan, "commit", "-q", "-m", "initial")
+	testutil.GitAt(t, tmpDir, "Alice", feb, "commit", "-q", "--allow-empty", "-m", "empty")
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "guide.md"), []byte("# Guide\n\nRewritten."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.GitAt(t, tmpDir, "Alice", mar, "commit", "-q", "-am", "rewrite guide")
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--recent", "2"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "- [docs/guide.md](docs/guide.md) — 2024-03-01 by Alice\n") {
+		t.Errorf("expected recent section from git history, got:\n%s", output)
+	}
+
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--git-info", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), `"lastCommit": "2024-03-01T00:00:00Z"`) ||
+		!strings.Contains(stdout.String(), `"commits": 2`) {
+		t.Errorf("expected git metadata in JSON, got:\n%s", stdout.String())
+	}
+
+	// Only an explicit --git-info requires a repository
+	if err := os.RemoveAll(filepath.Join(tmpDir, ".git")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--sort", "updated"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Errorf("expected mtime fallback outside a repository, got %v", err)
+	}
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--git-info"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("expected not a git repository error, got %v", err)
+	}
+}
+
+func TestChangedSinceFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	testutil.Git(t, tmpDir, "init", "-q")
+	testutil.Git(t, tmpDir, "add", "-A")
+	testutil.Git(t, tmpDir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, tmpDir, "tag", "v1")
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "guide.md"), []byte("# Guide\n\nRewritten."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, tmpDir, "rm", "-q", "README.md")
+	testutil.Git(t, tmpDir, "commit", "-q", "-am", "release")
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--changed-since", "v1", "--summary"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"[guide.md](docs/guide.md) *(modified)*", "> Rewritten.", "~~README.md~~ *(deleted)*"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "api.md") {
+		t.Errorf("unchanged files should be left out, got:\n%s", output)
+	}
+}
+
+func TestGitTrackedFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	testutil.Git(t, tmpDir, "init", "-q")
+	testutil.Git(t, tmpDir, "add", "README.md")
+	testutil.Git(t, tmpDir, "commit", "-q", "-m", "initial")
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--git-tracked"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "README.md") || strings.Contains(output, "guide.md") {
+		t.Errorf("expected only committed files, got:\n%s", output)
+	}
+
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--git-tracked", "--untracked"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "guide.md") {
+		t.Errorf("expected untracked files with --untracked, got:\n%s", output)
+	}
+}
+
+func TestIncludeFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir, "--include", "docs/**", "--include", "!docs/api/**"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "guide.md") {
+		t.Errorf("expected included guide.md, got:\n%s", output)
+	}
+	if strings.Contains(output, "README.md") || strings.Contains(output, "handlers.md") {
+		t.Errorf("expected files outside the include patterns to be dropped, got:\n%s", output)
+	}
+}
+
+func TestHiddenFlags(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	for name, content := range map[string]string{
+		".github/CONTRIBUTING.md": "# Contributing",
+		".changeset/brave-fox.md": "# Changeset",
+	} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		contains []string
+		excludes []string
+	}{
+		{"default skips hidden", nil, nil, []string{"CONTRIBUTING.md", "brave-fox.md", ".github"}},
+		{"github preset", []string{"--hidden-preset", "github"}, []string{"CONTRIBUTING.md"}, []string{"brave-fox.md"}},
+		{"allow-list", []string{"--allow-hidden", ".changeset"}, []string{"brave-fox.md"}, []string{"CONTRIBUTING.md"}},
+		{"hidden", []string{"--hidden"}, []string{"CONTRIBUTING.md", "brave-fox.md"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			var stdout bytes.Buffer
+			rootCmd.SetOut(&stdout)
+			rootCmd.SetErr(&stdout)
+			rootCmd.SetArgs(append([]string{tmpDir}, tt.args...))
+
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			output := stdout.String()
+			for _, want := range tt.contains {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected %q in output:\n%s", want, output)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("did not expect %q in output:\n%s", unwanted, output)
+				}
+			}
+		})
+	}
+
+	resetFlags()
+	rootCmd.SetArgs([]string{tmpDir, "--hidden-preset", "gitlab"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown hidden preset") {
+		t.Errorf("expected unknown preset error, got %v", err)
+	}
+}
+
+func TestFollowSymlinksFlag(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.Symlink(filepath.Join(tmpDir, "docs", "api"), filepath.Join(tmpDir, "reference")); err != nil {
+		t.Skipf("Cannot create symlinks on this system: %v", err)
+	}
+
+	resetFlags()
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stdout)
+	rootCmd.SetArgs([]string{tmpDir})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); strings.Contains(output, "reference") {
+		t.Errorf("expected symlinked directory to be skipped by default, got:\n%s", output)
+	}
+
+	resetFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{tmpDir, "--follow-symlinks"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := stdout.String(); !strings.Contains(output, "reference/ ↪") || !strings.Contains(output, "(reference/handlers.md)") {
+		t.Errorf("expected followed symlink with marker, got:\n%s", output)
+	}
+}
+
+// Helper functions
+
+func setupTestDir(t *testing.T) string {
+	t.Helper()
+
+	return testutil.TempDir(t, map[string]string{
+		"README.md":            "# README\n\nThis is the main readme file for the project.",
+		"docs/guide.md":        "# Guide\n\nGetting started guide for new users.",
+		"docs/api/handlers.md": "# Handlers\n\nAPI handler documentation.",
+	})
 }
 
 func resetFlags() {
 	// Reset all flags to default values
 	ignorePatterns = []string{}
+	includePatterns = []string{}
+	includeHidden = false
+	allowHidden = []string{}
+	hiddenPresets = []string{}
+	followSymlinks = false
+	followExternal = false
 	useGitignore = false
 	maxDepth = 0
 	includeSummary = false
 	summaryChars = 100
+	summaryMode = "paragraph"
 	singleThreaded = false
 	outputFile = ""
 	title = "Table of Contents"
 	fancy = false
+	outputFormat = ""
+	includeTags = false
+	keywordCount = 5
+	groupBy = ""
+	sortOrder = "name"
+	promoteIndex = false
+	compact = false
+	maxPerDir = 0
+	openDepth = 0
+	templateFile = ""
+	iconTheme = "emoji"
+	iconsFile = ""
+	showBadges = false
+	staleAfter = "12mo"
+	excludeDrafts = false
+	gitInfo = false
+	recentCount = 0
+	changedSince = ""
+	gitTracked = false
+	gitUntracked = false
+	searchLimit = 10
+	searchIndex = ""
+	searchRebuild = false
+	serveAddr = "localhost:8080"
 }
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/search"
+)
+
+var (
+	searchLimit   int
+	searchIndex   string
+	searchRebuild bool
+)
+
+// searchCmd runs a ranked full-text search over the scanned documents.
+var searchCmd = &cobra.Command{
+	Use:   "search <query> [directory]",
+	Short: "Search markdown files with relevance ranking",
+	Long: `search ranks the scanned markdown files against a query using BM25 over
+their text, headings and frontmatter, and prints path:line results with
+snippets. The index is cached in the user cache directory and rebuilt
+automatically when files change.
+
+Example:
+  go-toc search "deploy rollback"
+  go-toc search "auth token" ./docs --limit 5`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 10, "maximum number of results (0 = unlimited)")
+	searchCmd.Flags().StringVar(&searchIndex, "index", "", "index file location (default: user cache directory)")
+	searchCmd.Flags().BoolVar(&searchRebuild, "rebuild", false, "rebuild the index even if it is up to date")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	absPath, err := resolveDirectory(args[1:])
+	if err != nil {
+		return err
+	}
+
+	scannerConfig, err := newScannerConfig(absPath)
+	if err != nil {
+		return err
+	}
+
+	result, err := scanner.New(scannerConfig).ScanWithFiles()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	idx, err := openSearchIndex(absPath, result.Files)
+	if err != nil {
+		return err
+	}
+
+	results := idx.Search(args[0], searchLimit)
+	if len(results) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No matches found.")
+		return nil
+	}
+
+	for _, r := range results {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s\n", filepath.ToSlash(r.Path), r.Line, r.Snippet)
+	}
+
+	return nil
+}
+
+// openSearchIndex loads the cached index for root, rebuilding it if stale.
+func openSearchIndex(root string, files []string) (*search.Index, error) {
+	indexPath := searchIndex
+	if indexPath == "" {
+		var err error
+		if indexPath, err = search.CachePath(root); err != nil {
+			// No cache directory available; fall back to an in-memory index
+			return search.Build(root, files)
+		}
+	}
+
+	if searchRebuild {
+		idx, err := search.Build(root, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build index: %w", err)
+		}
+		if err := idx.Save(indexPath); err != nil {
+			return nil, fmt.Errorf("failed to save index: %w", err)
+		}
+		return idx, nil
+	}
+
+	idx, err := search.Open(indexPath, root, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	return idx, nil
+}
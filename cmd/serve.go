@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/danjdewhurst/go-toc/internal/server"
+)
+
+var serveAddr string
+
+// serveCmd previews the docs in a browser.
+var serveCmd = &cobra.Command{
+	Use:   "serve [directory]",
+	Short: "Preview the docs and their table of contents in a browser",
+	Long: `serve starts a local HTTP server that renders the table of contents as
+the home page and each markdown file as HTML when it is opened. Relative
+links between documents work as they do on GitHub, and open pages reload
+automatically when files change.
+
+Example:
+  go-toc serve ./docs
+  go-toc serve . --gitignore --addr localhost:3000`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "address to listen on")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	absPath, err := resolveDirectory(args)
+	if err != nil {
+		return err
+	}
+
+	scannerConfig, err := newScannerConfig(absPath)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+	}
+
+	httpServer := &http.Server{
+		Handler: server.New(server.Config{
+			Scanner:      scannerConfig,
+			Title:        title,
+			Addr:         serveAddr,
+			SummaryChars: summaryChars,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving %s at http://%s/\n", absPath, listener.Addr())
+	return httpServer.Serve(listener)
+}
@@ -0,0 +1,213 @@
+// Package gitinfo reads file history from a local git repository by
+// running the git command line tool. It never contacts a remote.
+package gitinfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotRepository is returned for directories outside a git working copy.
+var ErrNotRepository = errors.New("not a git repository")
+
+// File is the history of a single file.
+type File struct {
+	LastCommit time.Time // Committer date of the most recent commit touching the file
+	LastAuthor string    // Author name of that commit
+	Commits    int       // Number of commits touching the file
+}
+
+// Record and field separators for the log format, which cannot appear in
+// author names or paths.
+const (
+	recordSep = "\x1e"
+	fieldSep  = "\x1f"
+)
+
+// run executes git in dir and returns its standard output.
+func run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir, "-c", "core.quotePath=false"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "not a git repository") {
+			return nil, ErrNotRepository
+		}
+		if msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// History returns the history of every file below dir that has been
+// committed, keyed by slash-separated path relative to dir. Renames are
+// not followed, so a moved file's history starts at the move.
+func History(dir string) (map[string]File, error) {
+	out, err := run(dir, "log", "--relative", "--name-only", "--no-renames",
+		"--format="+recordSep+"%ct"+fieldSep+"%an", "--", ".")
+	if err != nil {
+		// A repository without commits has no history
+		if strings.Contains(err.Error(), "does not have any commits") {
+			return map[string]File{}, nil
+		}
+		return nil, err
+	}
+	return parseLog(string(out))
+}
+
+// parseLog parses log output, newest commit first, into file histories.
+func parseLog(log string) (map[string]File, error) {
+	files := make(map[string]File)
+
+	for _, record := range strings.Split(log, recordSep) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		header, names, _ := strings.Cut(record, "\n")
+		timestamp, author, ok := strings.Cut(header, fieldSep)
+		if !ok {
+			return nil, fmt.Errorf("unexpected git log line %q", header)
+		}
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected git log date %q", timestamp)
+		}
+		committed := time.Unix(seconds, 0)
+
+		for _, name := range strings.Split(names, "\n") {
+			if name == "" {
+				continue
+			}
+			file, seen := files[name]
+			if !seen {
+				file.LastCommit = committed
+				file.LastAuthor = author
+			}
+			file.Commits++
+			files[name] = file
+		}
+	}
+
+	return files, nil
+}
+
+// Change is how a file changed since a revision.
+type Change string
+
+const (
+	Added    Change = "added"
+	Modified Change = "modified"
+	Deleted  Change = "deleted"
+)
+
+// Changes returns the files below dir that differ between the revision ref
+// and the working tree, keyed by slash-separated path relative to dir.
+// This covers committed and uncommitted changes; untracked files that are
+// not ignored count as added. Renames show as a deletion and an addition.
+func Changes(dir, ref string) (map[string]Change, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid revision %q", ref)
+	}
+	if _, err := run(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		if errors.Is(err, ErrNotRepository) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unknown revision %q", ref)
+	}
+
+	out, err := run(dir, "diff", "--name-status", "--no-renames", "--relative", ref, "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := parseNameStatus(string(out))
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := run(dir, "ls-files", "--others", "--exclude-standard", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(string(untracked), "\n") {
+		if name != "" {
+			changes[name] = Added
+		}
+	}
+
+	return changes, nil
+}
+
+// parseNameStatus parses "git diff --name-status" output. Type changes and
+// unmerged files count as modified.
+func parseNameStatus(output string) (map[string]Change, error) {
+	changes := make(map[string]Change)
+
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		status, name, ok := strings.Cut(line, "\t")
+		if !ok || status == "" {
+			return nil, fmt.Errorf("unexpected git diff line %q", line)
+		}
+
+		switch status[0] {
+		case 'A':
+			changes[name] = Added
+		case 'D':
+			changes[name] = Deleted
+		default:
+			changes[name] = Modified
+		}
+	}
+
+	return changes, nil
+}
+
+// Files lists the files below dir in the git index, as slash-separated
+// paths relative to dir. With untracked, files that are not in the index
+// but not ignored either are listed too.
+func Files(dir string, untracked bool) ([]string, error) {
+	args := []string{"ls-files", "--cached"}
+	if untracked {
+		args = append(args, "--others", "--exclude-standard")
+	}
+	out, err := run(dir, append(args, "--", ".")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range strings.Split(string(out), "\n") {
+		if name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// ExcludesFile returns the core.excludesFile git uses for the repository
+// at dir, with a leading "~/" expanded, or "" when the option is unset.
+func ExcludesFile(dir string) (string, error) {
+	out, err := run(dir, "config", "--path", "--get", "core.excludesFile")
+	if err != nil {
+		// git config exits with 1 when the option is unset
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
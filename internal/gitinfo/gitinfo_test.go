@@ -0,0 +1,153 @@
+package gitinfo
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+)
+
+// newRepo creates an empty repository, skipping the test without git.
+func newRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir, err := os.MkdirTemp("", "go-toc-gitinfo-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, dir, "init", "-q")
+	return dir
+}
+
+// commitFiles writes files and commits them.
+func commitFiles(t *testing.T, dir string, date time.Time, author string, files map[string]string) {
+	t.Helper()
+
+	testutil.WriteFiles(t, dir, files)
+	testutil.GitAt(t, dir, author, date, "add", "-A")
+	testutil.GitAt(t, dir, author, date, "commit", "-q", "-m", "update")
+}
+
+func TestHistory(t *testing.T) {
+	dir := newRepo(t)
+	defer os.RemoveAll(dir)
+
+	first := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC)
+	commitFiles(t, dir, first, "Alice", map[string]string{
+		"README.md":      "# Readme",
+		"docs/guide.md":  "# Guide",
+		"docs/my doc.md": "# Spaces",
+		"docs/ümlaut.md": "# Unicode",
+	})
+	commitFiles(t, dir, second, "Bob", map[string]string{
+		"docs/guide.md": "# Guide\n\nUpdated.",
+	})
+
+	history, err := History(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guide := history["docs/guide.md"]
+	if !guide.LastCommit.Equal(second) || guide.LastAuthor != "Bob" || guide.Commits != 2 {
+		t.Errorf("unexpected guide history %+v", guide)
+	}
+	readme := history["README.md"]
+	if !readme.LastCommit.Equal(first) || readme.LastAuthor != "Alice" || readme.Commits != 1 {
+		t.Errorf("unexpected readme history %+v", readme)
+	}
+	for _, name := range []string{"docs/my doc.md", "docs/ümlaut.md"} {
+		if _, ok := history[name]; !ok {
+			t.Errorf("expected history for %q, got %v", name, history)
+		}
+	}
+
+	// Paths are relative to a subdirectory, which only sees its own files
+	sub, err := History(filepath.Join(dir, "docs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sub["guide.md"]; !ok || len(sub) != 3 {
+		t.Errorf("expected paths relative to docs, got %v", sub)
+	}
+}
+
+func TestHistoryEmptyRepository(t *testing.T) {
+	dir := newRepo(t)
+	defer os.RemoveAll(dir)
+
+	history, err := History(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 0 {
+		t.Errorf("expected no history, got %v", history)
+	}
+}
+
+func TestHistoryNotRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir, err := os.MkdirTemp("", "go-toc-gitinfo-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Stop git from finding a repository above the temp directory
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	if _, err := History(dir); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("expected ErrNotRepository, got %v", err)
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	log := "\x1e1700000000\x1fAlice\n\na.md\nb.md\n\x1e1600000000\x1fBob\n\na.md\n"
+
+	files, err := parseLog(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := files["a.md"]; a.Commits != 2 || a.LastAuthor != "Alice" || a.LastCommit.Unix() != 1700000000 {
+		t.Errorf("unexpected a.md history %+v", a)
+	}
+	if b := files["b.md"]; b.Commits != 1 || b.LastAuthor != "Alice" {
+		t.Errorf("unexpected b.md history %+v", b)
+	}
+
+	if _, err := parseLog("\x1egarbage\n"); err == nil {
+		t.Error("expected error for malformed log")
+	}
+}
+
+func TestExcludesFile(t *testing.T) {
+	dir := newRepo(t)
+	defer os.RemoveAll(dir)
+
+	home := filepath.Join(dir, "home")
+	t.Setenv("HOME", home)
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	if excludesFile, err := ExcludesFile(dir); err != nil || excludesFile != "" {
+		t.Errorf("expected no excludes file when unset, got %q (%v)", excludesFile, err)
+	}
+
+	testutil.Git(t, dir, "config", "core.excludesFile", "~/ignore")
+	expected := filepath.Join(home, "ignore")
+	if excludesFile, err := ExcludesFile(dir); err != nil || excludesFile != expected {
+		t.Errorf("expected %q, got %q (%v)", expected, excludesFile, err)
+	}
+}
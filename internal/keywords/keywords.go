@@ -0,0 +1,137 @@
+// Package keywords extracts the most characteristic terms of each document
+// in a corpus using TF-IDF.
+package keywords
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/danjdewhurst/go-toc/internal/parser"
+)
+
+// headingWeight is how much more a term in a heading counts than one in
+// running text.
+const headingWeight = 3.0
+
+// minTermLength is the minimum number of letters for a keyword.
+const minTermLength = 3
+
+// Corpus collects term frequencies for a set of documents so that terms
+// common to every document (like the project name) are ranked down.
+type Corpus struct {
+	terms map[string]map[string]float64 // path -> term -> weighted count
+	size  map[string]float64            // path -> total weighted count
+	df    map[string]int                // term -> number of documents containing it
+}
+
+// NewCorpus creates an empty corpus.
+func NewCorpus() *Corpus {
+	return &Corpus{
+		terms: make(map[string]map[string]float64),
+		size:  make(map[string]float64),
+		df:    make(map[string]int),
+	}
+}
+
+// Add counts the terms of a document's prose and headings.
+func (c *Corpus) Add(path string, doc *parser.Document) {
+	counts := make(map[string]float64)
+	total := 0.0
+
+	add := func(text string, weight float64) {
+		for _, term := range Terms(text) {
+			counts[term] += weight
+			total += weight
+		}
+	}
+
+	for _, line := range doc.Prose() {
+		if !strings.HasPrefix(line, "#") {
+			add(line, 1)
+		}
+	}
+	for _, h := range doc.Headings {
+		add(h.Text, headingWeight)
+	}
+
+	if old, exists := c.terms[path]; exists {
+		for term := range old {
+			c.df[term]--
+		}
+	}
+	for term := range counts {
+		c.df[term]++
+	}
+
+	c.terms[path] = counts
+	c.size[path] = total
+}
+
+// Keywords returns up to n terms of the document ranked by TF-IDF.
+func (c *Corpus) Keywords(path string, n int) []string {
+	counts := c.terms[path]
+	if len(counts) == 0 || n <= 0 {
+		return nil
+	}
+
+	type scored struct {
+		term  string
+		score float64
+	}
+
+	docs := float64(len(c.terms))
+	ranked := make([]scored, 0, len(counts))
+	for term, count := range counts {
+		tf := count / c.size[path]
+		idf := math.Log((docs+1)/(float64(c.df[term])+1)) + 1
+		ranked = append(ranked, scored{term: term, score: tf * idf})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].term < ranked[j].term
+	})
+
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+
+	keywords := make([]string, len(ranked))
+	for i, s := range ranked {
+		keywords[i] = s.term
+	}
+	return keywords
+}
+
+// Terms splits text into lowercase candidate keywords, dropping stopwords,
+// numbers and very short words.
+func Terms(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	})
+
+	terms := fields[:0]
+	for _, field := range fields {
+		field = strings.Trim(field, "-")
+		if countLetters(field) < minTermLength || parser.IsStopword(field) {
+			continue
+		}
+		terms = append(terms, field)
+	}
+	return terms
+}
+
+// countLetters returns the number of letters in s.
+func countLetters(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
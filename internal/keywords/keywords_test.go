@@ -0,0 +1,76 @@
+package keywords
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/danjdewhurst/go-toc/internal/parser"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"The deploy pipeline and the rollback", []string{"deploy", "pipeline", "rollback"}},
+		{"Die Konfiguration und der Server", []string{"konfiguration", "server"}},
+		{"v1.2 in 2024, go-toc rocks", []string{"go-toc", "rocks"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if result := Terms(tt.text); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Terms(%q) = %v, expected %v", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCorpusKeywords(t *testing.T) {
+	corpus := NewCorpus()
+	corpus.Add("deploy.md", newDoc("# Deploy with Kubernetes\n\nThe project deploys to kubernetes clusters. Each kubernetes cluster runs the project."))
+	corpus.Add("auth.md", newDoc("# Authentication\n\nThe project uses tokens. Tokens expire after an hour."))
+	corpus.Add("code.md", newDoc("# Example\n\n```go\nkubernetes kubernetes kubernetes\n```\n\nThe project example."))
+
+	result := corpus.Keywords("deploy.md", 2)
+	if !reflect.DeepEqual(result, []string{"kubernetes", "deploy"}) {
+		t.Errorf("expected [kubernetes deploy], got %v", result)
+	}
+
+	// Terms shared by every document rank below distinctive ones
+	auth := corpus.Keywords("auth.md", 2)
+	if !reflect.DeepEqual(auth, []string{"authentication", "tokens"}) {
+		t.Errorf("expected [authentication tokens], got %v", auth)
+	}
+
+	// Code blocks are not prose
+	for _, kw := range corpus.Keywords("code.md", 5) {
+		if kw == "kubernetes" {
+			t.Error("terms inside code blocks should be ignored")
+		}
+	}
+
+	if corpus.Keywords("missing.md", 5) != nil {
+		t.Error("expected nil keywords for unknown document")
+	}
+	if corpus.Keywords("deploy.md", 0) != nil {
+		t.Error("expected nil keywords when n is 0")
+	}
+}
+
+func TestCorpusReAdd(t *testing.T) {
+	corpus := NewCorpus()
+	corpus.Add("a.md", newDoc("alpha beta"))
+	corpus.Add("a.md", newDoc("gamma delta"))
+
+	if corpus.df["alpha"] != 0 || corpus.df["gamma"] != 1 {
+		t.Errorf("re-adding a document should replace its terms, got df %v", corpus.df)
+	}
+}
+
+// Helper functions
+
+func newDoc(content string) *parser.Document {
+	return parser.NewDocument(strings.Split(content, "\n"))
+}
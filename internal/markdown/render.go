@@ -0,0 +1,662 @@
+// Package markdown renders a practical subset of CommonMark and GitHub
+// Flavored Markdown to HTML: headings, paragraphs, emphasis, code, links,
+// images, lists, blockquotes, tables and rules. It is meant for previewing
+// documentation, not as a complete implementation of the spec.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	orderedItem    = regexp.MustCompile(`^(\d{1,9})[.)](\s+|$)`)
+	tableSeparator = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	unsafeScheme   = regexp.MustCompile(`(?i)^\s*(javascript|vbscript|data):`)
+)
+
+// ToHTML renders markdown source to HTML. Raw HTML blocks keep a safe
+// subset of tags and attributes; everything else is escaped.
+func ToHTML(source string) string {
+	r := &renderer{slugs: make(map[string]int)}
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	r.blocks(lines)
+	return r.sb.String()
+}
+
+// renderer accumulates output and tracks heading IDs so they stay unique.
+type renderer struct {
+	sb    strings.Builder
+	slugs map[string]int
+}
+
+// blocks renders a sequence of lines as block-level elements.
+func (r *renderer) blocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case isFence(trimmed):
+			i = r.codeBlock(lines, i)
+		case strings.HasPrefix(trimmed, "#") && headingLevel(trimmed) > 0:
+			r.heading(trimmed)
+			i++
+		case isRule(trimmed):
+			r.sb.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			i = r.blockquote(lines, i)
+		case listMarker(line) != "":
+			i = r.list(lines, i)
+		case isTableStart(lines, i):
+			i = r.table(lines, i)
+		case strings.HasPrefix(trimmed, "<"):
+			i = r.htmlBlock(lines, i)
+		default:
+			i = r.paragraph(lines, i)
+		}
+	}
+}
+
+// isFence reports whether a trimmed line opens or closes a code block.
+func isFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// codeBlock renders a fenced code block starting at lines[start].
+func (r *renderer) codeBlock(lines []string, start int) int {
+	opening := strings.TrimSpace(lines[start])
+	fence := opening[:3]
+	lang := strings.Fields(strings.TrimLeft(opening, fence[:1]) + " ")
+
+	if len(lang) > 0 {
+		fmt.Fprintf(&r.sb, "<pre><code class=\"language-%s\">", html.EscapeString(lang[0]))
+	} else {
+		r.sb.WriteString("<pre><code>")
+	}
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			i++
+			break
+		}
+		r.sb.WriteString(html.EscapeString(lines[i]))
+		r.sb.WriteString("\n")
+	}
+
+	r.sb.WriteString("</code></pre>\n")
+	return i
+}
+
+// headingLevel returns the level of an ATX heading, or 0 if the line is not one.
+func headingLevel(trimmed string) int {
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level > 6 || (level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t') {
+		return 0
+	}
+	return level
+}
+
+// heading renders an ATX heading with a GitHub-style id for anchor links.
+func (r *renderer) heading(trimmed string) {
+	level := headingLevel(trimmed)
+	text := strings.TrimSpace(trimmed[level:])
+
+	// Closing #s are only stripped when separated by a space, so "C#" survives
+	if stripped := strings.TrimRight(text, "#"); stripped != text && (stripped == "" || strings.HasSuffix(stripped, " ")) {
+		text = strings.TrimSpace(stripped)
+	}
+
+	fmt.Fprintf(&r.sb, "<h%d id=\"%s\">%s</h%d>\n", level, r.slug(text), inline(text), level)
+}
+
+// slug returns a unique GitHub-style anchor for heading text.
+func (r *renderer) slug(text string) string {
+	var sb strings.Builder
+	for _, c := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '-' || c == '_':
+			sb.WriteRune(c)
+		case c == ' ':
+			sb.WriteRune('-')
+		}
+	}
+
+	slug := sb.String()
+	if n := r.slugs[slug]; n > 0 {
+		r.slugs[slug]++
+		return fmt.Sprintf("%s-%d", slug, n)
+	}
+	r.slugs[slug] = 1
+	return slug
+}
+
+// isRule reports whether a trimmed line is a thematic break.
+func isRule(trimmed string) bool {
+	if len(trimmed) < 3 {
+		return false
+	}
+	marker := trimmed[0]
+	if marker != '-' && marker != '*' && marker != '_' {
+		return false
+	}
+	count := 0
+	for _, c := range trimmed {
+		switch {
+		case byte(c) == marker:
+			count++
+		case c != ' ':
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// blockquote renders consecutive ">" lines, with their content rendered as blocks.
+func (r *renderer) blockquote(lines []string, start int) int {
+	var inner []string
+	i := start
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		inner = append(inner, strings.TrimPrefix(strings.TrimPrefix(trimmed, ">"), " "))
+	}
+
+	r.sb.WriteString("<blockquote>\n")
+	r.blocks(inner)
+	r.sb.WriteString("</blockquote>\n")
+	return i
+}
+
+// listMarker returns the marker of a list item line ("-", "*", "+" or the
+// number of an ordered item), or "" if the line does not start an item.
+func listMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	if len(trimmed) >= 2 && strings.ContainsRune("-*+", rune(trimmed[0])) && (trimmed[1] == ' ' || trimmed[1] == '\t') {
+		if isRule(strings.TrimSpace(trimmed)) {
+			return ""
+		}
+		return trimmed[:1]
+	}
+	if m := orderedItem.FindStringSubmatch(trimmed); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// indentOf returns the number of leading spaces of a line, counting tabs as four.
+func indentOf(line string) int {
+	n := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// dedent removes up to n columns of leading whitespace from a line.
+func dedent(line string, n int) string {
+	i := 0
+	for col := 0; i < len(line) && col < n; i++ {
+		switch line[i] {
+		case ' ':
+			col++
+		case '\t':
+			col += 4
+		default:
+			return line[i:]
+		}
+	}
+	return line[i:]
+}
+
+// list renders a bulleted or numbered list. Lines indented past an item's
+// marker belong to that item and are rendered as blocks, which handles
+// nested lists and multi-paragraph items.
+func (r *renderer) list(lines []string, start int) int {
+	base := indentOf(lines[start])
+	ordered := orderedItem.MatchString(strings.TrimLeft(lines[start], " \t"))
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if n := listMarker(lines[start]); n != "1" {
+			fmt.Fprintf(&r.sb, "<ol start=\"%s\">\n", n)
+		} else {
+			r.sb.WriteString("<ol>\n")
+		}
+	} else {
+		r.sb.WriteString("<ul>\n")
+	}
+
+	var items [][]string
+	loose := false
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		indent := indentOf(line)
+
+		if strings.TrimSpace(line) == "" {
+			// A blank line ends the list unless more of it follows
+			next := i + 1
+			if next < len(lines) && (indentOf(lines[next]) > base || (listMarker(lines[next]) != "" && indentOf(lines[next]) == base)) {
+				if len(items) > 0 {
+					items[len(items)-1] = append(items[len(items)-1], "")
+				}
+				loose = loose || indentOf(lines[next]) == base
+				continue
+			}
+			break
+		}
+
+		if indent <= base+1 && listMarker(line) != "" {
+			if orderedItem.MatchString(strings.TrimLeft(line, " \t")) != ordered {
+				break
+			}
+			content := strings.TrimLeft(line, " \t")[len(listMarker(line)):]
+			if ordered {
+				content = content[1:] // The "." or ")" after the number
+			}
+			content = strings.TrimLeft(content, " \t")
+			items = append(items, []string{content})
+			continue
+		}
+
+		if len(items) == 0 || (indent <= base && isBlockStart(line)) {
+			break
+		}
+		// Continuation of the current item, dedented to its content
+		items[len(items)-1] = append(items[len(items)-1], dedent(line, base+2))
+	}
+
+	for _, item := range items {
+		r.sb.WriteString("<li>")
+		r.listItem(item, loose)
+		r.sb.WriteString("</li>\n")
+	}
+
+	fmt.Fprintf(&r.sb, "</%s>\n", tag)
+	return i
+}
+
+// listItem renders the content of a list item. Tight items render their
+// first paragraph without <p> tags, like GitHub does.
+func (r *renderer) listItem(lines []string, loose bool) {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// Task list checkboxes
+	if len(lines) > 0 {
+		switch {
+		case strings.HasPrefix(lines[0], "[ ] "):
+			r.sb.WriteString(`<input type="checkbox" disabled> `)
+			lines[0] = lines[0][4:]
+		case strings.HasPrefix(lines[0], "[x] "), strings.HasPrefix(lines[0], "[X] "):
+			r.sb.WriteString(`<input type="checkbox" checked disabled> `)
+			lines[0] = lines[0][4:]
+		}
+	}
+
+	if loose {
+		r.sb.WriteString("\n")
+		r.blocks(lines)
+		return
+	}
+
+	// Leading text up to the first nested block renders inline
+	end := 0
+	for end < len(lines) && strings.TrimSpace(lines[end]) != "" && (end == 0 || !isBlockStart(lines[end])) {
+		end++
+	}
+	r.sb.WriteString(inline(joinLines(lines[:end])))
+	if end < len(lines) {
+		r.sb.WriteString("\n")
+		r.blocks(lines[end:])
+	}
+}
+
+// isBlockStart reports whether a line starts a block other than a paragraph.
+func isBlockStart(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return isFence(trimmed) ||
+		(strings.HasPrefix(trimmed, "#") && headingLevel(trimmed) > 0) ||
+		isRule(trimmed) ||
+		strings.HasPrefix(trimmed, ">") ||
+		listMarker(line) != ""
+}
+
+// isTableStart reports whether lines[i] is a table header followed by a
+// delimiter row.
+func isTableStart(lines []string, i int) bool {
+	return i+1 < len(lines) &&
+		strings.Contains(lines[i], "|") &&
+		strings.Contains(lines[i+1], "-") &&
+		tableSeparator.MatchString(strings.TrimSpace(lines[i+1]))
+}
+
+// table renders a GitHub-style pipe table.
+func (r *renderer) table(lines []string, start int) int {
+	header := tableCells(lines[start])
+	delims := tableCells(lines[start+1])
+
+	aligns := make([]string, len(header))
+	for j := range aligns {
+		if j >= len(delims) {
+			break
+		}
+		d := delims[j]
+		switch {
+		case strings.HasPrefix(d, ":") && strings.HasSuffix(d, ":"):
+			aligns[j] = "center"
+		case strings.HasSuffix(d, ":"):
+			aligns[j] = "right"
+		case strings.HasPrefix(d, ":"):
+			aligns[j] = "left"
+		}
+	}
+
+	writeRow := func(cells []string, tag string) {
+		r.sb.WriteString("<tr>")
+		for j := range header {
+			cell := ""
+			if j < len(cells) {
+				cell = cells[j]
+			}
+			if aligns[j] != "" {
+				fmt.Fprintf(&r.sb, "<%s style=\"text-align: %s\">%s</%s>", tag, aligns[j], inline(cell), tag)
+			} else {
+				fmt.Fprintf(&r.sb, "<%s>%s</%s>", tag, inline(cell), tag)
+			}
+		}
+		r.sb.WriteString("</tr>\n")
+	}
+
+	r.sb.WriteString("<table>\n<thead>\n")
+	writeRow(header, "th")
+	r.sb.WriteString("</thead>\n<tbody>\n")
+
+	i := start + 2
+	for ; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" || !strings.Contains(lines[i], "|") {
+			break
+		}
+		writeRow(tableCells(lines[i]), "td")
+	}
+
+	r.sb.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// tableCells splits a table row into trimmed cells, honouring escaped pipes.
+func tableCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// htmlBlock renders raw HTML up to the next blank line, sanitized.
+func (r *renderer) htmlBlock(lines []string, start int) int {
+	i := start
+	for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+		i++
+	}
+	r.sb.WriteString(sanitizeHTML(strings.Join(lines[start:i], "\n")))
+	r.sb.WriteString("\n")
+	return i
+}
+
+// paragraph renders consecutive text lines as a paragraph.
+func (r *renderer) paragraph(lines []string, start int) int {
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" || isBlockStart(lines[i]) {
+			break
+		}
+	}
+
+	r.sb.WriteString("<p>")
+	r.sb.WriteString(inline(joinLines(lines[start:i])))
+	r.sb.WriteString("</p>\n")
+	return i
+}
+
+// joinLines joins paragraph lines, turning two trailing spaces or a
+// trailing backslash into a hard line break.
+func joinLines(lines []string) string {
+	var sb strings.Builder
+	for i, line := range lines {
+		line = strings.TrimLeft(line, " \t")
+		if i == len(lines)-1 {
+			sb.WriteString(strings.TrimRight(line, " \t"))
+			break
+		}
+		switch {
+		case strings.HasSuffix(line, "  "):
+			sb.WriteString(strings.TrimRight(line, " "))
+			sb.WriteString("\x00")
+		case strings.HasSuffix(line, `\`):
+			sb.WriteString(strings.TrimSuffix(line, `\`))
+			sb.WriteString("\x00")
+		default:
+			sb.WriteString(strings.TrimRight(line, " \t"))
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// inlineDelimiters are the emphasis markers, longest first.
+var inlineDelimiters = []struct {
+	marker string
+	tag    string
+}{
+	{"**", "strong"},
+	{"__", "strong"},
+	{"~~", "del"},
+	{"*", "em"},
+	{"_", "em"},
+}
+
+// inline renders inline markdown: code spans, links, images, autolinks,
+// emphasis and escapes. All other text is HTML-escaped.
+func inline(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\x00':
+			sb.WriteString("<br>\n")
+			i++
+			continue
+
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!|~<>", text[i+1]) != -1:
+			sb.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if n, ok := codeSpan(&sb, text[i:]); ok {
+				i += n
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(text[i:], "!["):
+			if label, dest, n, ok := parseLink(text[i+1:]); ok {
+				fmt.Fprintf(&sb, "<img src=\"%s\" alt=\"%s\">", safeURL(dest), html.EscapeString(label))
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if label, dest, n, ok := parseLink(text[i:]); ok {
+				fmt.Fprintf(&sb, "<a href=\"%s\">%s</a>", safeURL(dest), inline(label))
+				i += n
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 {
+				target := text[i+1 : i+end]
+				if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "mailto:") {
+					fmt.Fprintf(&sb, "<a href=\"%s\">%s</a>", safeURL(target), html.EscapeString(target))
+					i += end + 1
+					continue
+				}
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if n, ok := emphasis(&sb, text, i); ok {
+				i += n
+				continue
+			}
+		}
+
+		sb.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+
+	return sb.String()
+}
+
+// codeSpan renders a code span at the start of text and returns its length.
+func codeSpan(sb *strings.Builder, text string) (int, bool) {
+	ticks := len(text) - len(strings.TrimLeft(text, "`"))
+	fence := text[:ticks]
+	end := strings.Index(text[ticks:], fence)
+	if end == -1 {
+		return 0, false
+	}
+
+	code := strings.ReplaceAll(text[ticks:ticks+end], "\n", " ")
+	if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+		code = code[1 : len(code)-1]
+	}
+	sb.WriteString("<code>")
+	sb.WriteString(html.EscapeString(code))
+	sb.WriteString("</code>")
+	return ticks + end + ticks, true
+}
+
+// parseLink parses "[label](destination)" at the start of text and returns
+// its parts and total length.
+func parseLink(text string) (label, dest string, n int, ok bool) {
+	closeBracket := matching(text, 0, '[', ']')
+	if closeBracket == -1 || closeBracket+1 >= len(text) || text[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+	closeParen := matching(text, closeBracket+1, '(', ')')
+	if closeParen == -1 {
+		return "", "", 0, false
+	}
+
+	dest = strings.TrimSpace(text[closeBracket+2 : closeParen])
+	// Drop an optional title: [label](dest "title")
+	if sp := strings.IndexAny(dest, " \t"); sp != -1 {
+		dest = dest[:sp]
+	}
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+
+	return text[1:closeBracket], dest, closeParen + 1, true
+}
+
+// matching returns the index of the bracket closing the one at start, or -1.
+func matching(text string, start int, open, close byte) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// emphasis renders an emphasis span starting at text[i] and returns its length.
+func emphasis(sb *strings.Builder, text string, i int) (int, bool) {
+	for _, d := range inlineDelimiters {
+		if !strings.HasPrefix(text[i:], d.marker) {
+			continue
+		}
+
+		// Underscores inside words (snake_case) are not emphasis
+		if d.marker[0] == '_' && i > 0 && isWordChar(rune(text[i-1])) {
+			return 0, false
+		}
+
+		start := i + len(d.marker)
+		if start >= len(text) || text[start] == ' ' {
+			continue
+		}
+		end := strings.Index(text[start:], d.marker)
+		if end <= 0 || text[start+end-1] == ' ' {
+			continue
+		}
+		after := start + end + len(d.marker)
+		if d.marker[0] == '_' && after < len(text) && isWordChar(rune(text[after])) {
+			continue
+		}
+
+		fmt.Fprintf(sb, "<%s>%s</%s>", d.tag, inline(text[start:start+end]), d.tag)
+		return after - i, true
+	}
+	return 0, false
+}
+
+// isWordChar reports whether c is a letter or digit.
+func isWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// safeURL escapes a link destination and neutralizes script URLs.
+func safeURL(dest string) string {
+	if unsafeScheme.MatchString(dest) {
+		return "#"
+	}
+	return html.EscapeString(dest)
+}
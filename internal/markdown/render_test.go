@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToHTMLBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "headings with ids",
+			input:    "# Getting Started\n\n## C#\n\n## Getting Started",
+			expected: "<h1 id=\"getting-started\">Getting Started</h1>\n<h2 id=\"c\">C#</h2>\n<h2 id=\"getting-started-1\">Getting Started</h2>\n",
+		},
+		{
+			name:     "paragraphs",
+			input:    "First line\nsame paragraph.\n\nSecond paragraph.",
+			expected: "<p>First line\nsame paragraph.</p>\n<p>Second paragraph.</p>\n",
+		},
+		{
+			name:     "hard line break",
+			input:    "one  \ntwo",
+			expected: "<p>one<br>\ntwo</p>\n",
+		},
+		{
+			name:     "code block",
+			input:    "```go\nif a < b {}\n```",
+			expected: "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n",
+		},
+		{
+			name:     "unordered list",
+			input:    "- one\n- .env\n  - nested",
+			expected: "<ul>\n<li>one</li>\n<li>.env\n<ul>\n<li>nested</li>\n</ul>\n</li>\n</ul>\n",
+		},
+		{
+			name:     "ordered list",
+			input:    "3. three\n4. four",
+			expected: "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n",
+		},
+		{
+			name:     "task list",
+			input:    "- [x] done\n- [ ] todo",
+			expected: "<ul>\n<li><input type=\"checkbox\" checked disabled> done</li>\n<li><input type=\"checkbox\" disabled> todo</li>\n</ul>\n",
+		},
+		{
+			name:     "blockquote",
+			input:    "> quoted *text*",
+			expected: "<blockquote>\n<p>quoted <em>text</em></p>\n</blockquote>\n",
+		},
+		{
+			name:     "rule",
+			input:    "above\n\n---\n\nbelow",
+			expected: "<p>above</p>\n<hr>\n<p>below</p>\n",
+		},
+		{
+			name:     "table",
+			input:    "| Flag | Default |\n|------|--------:|\n| `-s` | false |",
+			expected: "<table>\n<thead>\n<tr><th>Flag</th><th style=\"text-align: right\">Default</th></tr>\n</thead>\n<tbody>\n<tr><td><code>-s</code></td><td style=\"text-align: right\">false</td></tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name:     "raw html block",
+			input:    "<div align=\"center\">\n<img src=\"logo.png\">\n</div>",
+			expected: "<div align=\"center\">\n<img src=\"logo.png\">\n</div>\n",
+		},
+		{
+			name:     "raw html script",
+			input:    "<script>\nfetch('/x')\n</script>",
+			expected: "&lt;script&gt;\nfetch('/x')\n&lt;/script&gt;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ToHTML(tt.input); result != tt.expected {
+				t.Errorf("ToHTML(%q) =\n%s\nwant:\n%s", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"**bold** and *italic*", "<strong>bold</strong> and <em>italic</em>"},
+		{"__bold__ and _italic_ and ~~gone~~", "<strong>bold</strong> and <em>italic</em> and <del>gone</del>"},
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"use `a <b>` here", "use <code>a &lt;b&gt;</code> here"},
+		{"[the **guide**](docs/guide.md)", "<a href=\"docs/guide.md\">the <strong>guide</strong></a>"},
+		{"[titled](a.md \"Title\")", "<a href=\"a.md\">titled</a>"},
+		{"![logo](img/logo.png)", "<img src=\"img/logo.png\" alt=\"logo\">"},
+		{"<https://example.com>", "<a href=\"https://example.com\">https://example.com</a>"},
+		{"[click](javascript:alert(1))", "<a href=\"#\">click</a>"},
+		{"5 * 3 < 20 & \\*not emphasis\\*", "5 * 3 &lt; 20 &amp; *not emphasis*"},
+	}
+
+	for _, tt := range tests {
+		if result := inline(tt.input); result != tt.expected {
+			t.Errorf("inline(%q) = %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestToHTMLLooseList(t *testing.T) {
+	result := ToHTML("- first\n\n- second\n\n  more about second")
+	for _, want := range []string{"<li>\n<p>first</p>\n</li>", "<p>second</p>\n<p>more about second</p>"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in loose list output, got:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTag       = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^\s"'=<>/]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*)\s*(/?)>`)
+	htmlAttribute = regexp.MustCompile(`([^\s"'=<>/]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	htmlComment   = regexp.MustCompile(`(?s)^<!--.*?-->`)
+)
+
+// allowedTags are the raw HTML elements kept by sanitizeHTML, the layout
+// and formatting tags commonly found in READMEs.
+var allowedTags = map[string]bool{
+	"a": true, "abbr": true, "b": true, "blockquote": true, "br": true,
+	"code": true, "dd": true, "del": true, "details": true, "div": true,
+	"dl": true, "dt": true, "em": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "hr": true, "i": true, "img": true,
+	"kbd": true, "li": true, "ol": true, "p": true, "picture": true,
+	"pre": true, "s": true, "source": true, "span": true, "strong": true,
+	"sub": true, "summary": true, "sup": true, "table": true, "tbody": true,
+	"td": true, "th": true, "thead": true, "tr": true, "ul": true,
+}
+
+// allowedAttributes are the attributes kept on allowed tags. URL
+// attributes go through safeURL.
+var allowedAttributes = map[string]bool{
+	"align": true, "alt": true, "colspan": true, "height": true,
+	"href": true, "media": true, "open": true, "rowspan": true,
+	"src": true, "srcset": true, "title": true, "width": true,
+}
+
+// sanitizeHTML keeps the allowed tags and attributes of raw HTML and
+// escapes everything else, so scripts, styles, frames and event handlers
+// show up as text instead of running. Comments are dropped.
+func sanitizeHTML(raw string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(raw); {
+		if raw[i] != '<' {
+			end := strings.IndexByte(raw[i:], '<')
+			if end < 0 {
+				end = len(raw) - i
+			}
+			sb.WriteString(strings.ReplaceAll(raw[i:i+end], ">", "&gt;"))
+			i += end
+			continue
+		}
+
+		if comment := htmlComment.FindString(raw[i:]); comment != "" {
+			i += len(comment)
+			continue
+		}
+
+		m := htmlTag.FindStringSubmatch(raw[i:])
+		if m == nil || !allowedTags[strings.ToLower(m[2])] {
+			n := 1
+			if m != nil {
+				n = len(m[0])
+			}
+			sb.WriteString(html.EscapeString(raw[i : i+n]))
+			i += n
+			continue
+		}
+
+		closing, name, attrs, selfClosing := m[1], strings.ToLower(m[2]), m[3], m[4]
+		sb.WriteString("<" + closing + name)
+		if closing == "" {
+			for _, attr := range htmlAttribute.FindAllStringSubmatch(attrs, -1) {
+				key := strings.ToLower(attr[1])
+				if !allowedAttributes[key] {
+					continue
+				}
+				value := html.UnescapeString(attr[2] + attr[3] + attr[4])
+				if key == "href" || key == "src" || key == "srcset" {
+					fmt.Fprintf(&sb, " %s=\"%s\"", key, safeURL(value))
+				} else {
+					fmt.Fprintf(&sb, " %s=\"%s\"", key, html.EscapeString(value))
+				}
+			}
+		}
+		sb.WriteString(selfClosing + ">")
+		i += len(m[0])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package markdown
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "allowed tags and attributes",
+			input:    `<p align="center"><img src="logo.png" alt="Logo" width=120></p>`,
+			expected: `<p align="center"><img src="logo.png" alt="Logo" width="120"></p>`,
+		},
+		{
+			name:     "script escaped",
+			input:    `<script>alert("hi")</script>`,
+			expected: `&lt;script&gt;alert("hi")&lt;/script&gt;`,
+		},
+		{
+			name:     "event handlers and styles dropped",
+			input:    `<div onclick="steal()" style="color: red">Text</div>`,
+			expected: `<div>Text</div>`,
+		},
+		{
+			name:     "script URLs neutralized",
+			input:    `<a href="javascript:alert(1)">x</a>`,
+			expected: `<a href="#">x</a>`,
+		},
+		{
+			name:     "frames escaped",
+			input:    `<iframe src="https://example.com"></iframe>`,
+			expected: `&lt;iframe src=&#34;https://example.com&#34;&gt;&lt;/iframe&gt;`,
+		},
+		{
+			name:     "comments dropped",
+			input:    `<!-- note --><br/>`,
+			expected: `<br/>`,
+		},
+		{
+			name:     "stray brackets escaped",
+			input:    `<div>1 < 2 > 0</div>`,
+			expected: `<div>1 &lt; 2 &gt; 0</div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := sanitizeHTML(tt.input); result != tt.expected {
+				t.Errorf("sanitizeHTML(%q) =\n%s\nwant:\n%s", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
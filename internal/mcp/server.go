@@ -0,0 +1,223 @@
+// Package mcp implements a Model Context Protocol server that exposes the
+// scanned documentation tree to MCP clients over stdio.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+)
+
+// ProtocolVersion is the newest MCP protocol revision the server speaks.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions lists protocol revisions accepted during initialization.
+var supportedVersions = []string{"2024-11-05", "2025-03-26", ProtocolVersion}
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Config holds options for the MCP server.
+type Config struct {
+	Scanner      scanner.Config // Scanner options used to discover documents
+	Title        string         // Title used when rendering the ToC
+	SummaryChars int            // Maximum characters for summaries
+	Version      string         // Server version reported to clients
+}
+
+// Server answers MCP requests using newline-delimited JSON-RPC messages.
+type Server struct {
+	config Config
+	tools  []tool
+}
+
+// request is an incoming JSON-RPC request or notification.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewServer creates a new MCP server.
+func NewServer(config Config) *Server {
+	if config.Title == "" {
+		config.Title = "Table of Contents"
+	}
+	if config.Version == "" {
+		config.Version = "dev"
+	}
+
+	s := &Server{config: config}
+	s.tools = s.registerTools()
+
+	return s
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted.
+// Each message occupies a single line, as required by the MCP stdio transport.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	encoder := json.NewEncoder(w)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if resp := s.handleMessage(line); resp != nil {
+				if encErr := encoder.Encode(resp); encErr != nil {
+					return encErr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleMessage processes one raw message. It returns nil for notifications
+// and blank lines, which must not be answered.
+func (s *Server) handleMessage(line []byte) *response {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, "parse error")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		id := req.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		return errorResponse(id, codeInvalidRequest, "invalid request")
+	}
+
+	// Notifications carry no ID and never get a response
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	result, rpcErr := s.dispatch(req.Method, req.Params)
+	if rpcErr != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch routes a request to its method handler.
+func (s *Server) dispatch(method string, params json.RawMessage) (any, *rpcError) {
+	switch method {
+	case "initialize":
+		return s.initialize(params), nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+	}
+}
+
+// initialize negotiates the protocol version and advertises capabilities.
+func (s *Server) initialize(params json.RawMessage) map[string]any {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	_ = json.Unmarshal(params, &p)
+
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools": map[string]any{},
+		},
+		"serverInfo": map[string]any{
+			"name":    "go-toc",
+			"version": s.config.Version,
+		},
+	}
+}
+
+// callTool runs a tool and wraps its output as MCP text content.
+// Tool failures are reported in the result so the model can see them.
+func (s *Server) callTool(params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid params"}
+	}
+
+	idx := slices.IndexFunc(s.tools, func(t tool) bool { return t.Name == p.Name })
+	if idx == -1 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+	}
+
+	args := p.Arguments
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+
+	text, err := s.tools[idx].handler(args)
+	if err != nil {
+		return toolResult(err.Error(), true), nil
+	}
+
+	return toolResult(text, false), nil
+}
+
+// toolResult builds a tools/call result with a single text block.
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{
+			{"type": "text", "text": text},
+		},
+		"isError": isError,
+	}
+}
+
+// errorResponse builds a JSON-RPC error response.
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	return &response{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &rpcError{Code: code, Message: message},
+	}
+}
@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+)
+
+func TestServerInitialize(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	responses := serve(t, tmpDir,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	)
+
+	// The notification must not produce a response
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+
+	result := responses[0]["result"].(map[string]any)
+	if result["protocolVersion"] != "2024-11-05" {
+		t.Errorf("expected negotiated version 2024-11-05, got %v", result["protocolVersion"])
+	}
+	if _, ok := result["capabilities"].(map[string]any)["tools"]; !ok {
+		t.Error("expected tools capability")
+	}
+}
+
+func TestServerToolsList(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	responses := serve(t, tmpDir, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	tools := responses[0]["result"].(map[string]any)["tools"].([]any)
+	var names []string
+	for _, tl := range tools {
+		names = append(names, tl.(map[string]any)["name"].(string))
+	}
+
+	for _, want := range []string{"list_docs", "get_toc", "read_doc", "search_docs"} {
+		if !strings.Contains(strings.Join(names, ","), want) {
+			t.Errorf("expected tool %s, got %v", want, names)
+		}
+	}
+}
+
+func TestServerTools(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name        string
+		call        string
+		wantError   bool
+		wantContain []string
+		wantExclude []string
+	}{
+		{
+			name:        "list_docs",
+			call:        `{"name":"list_docs"}`,
+			wantContain: []string{`"path": "docs/guide.md"`, `"title": "Guide"`, "Getting started guide"},
+		},
+		{
+			name:        "get_toc",
+			call:        `{"name":"get_toc","arguments":{"summary":true}}`,
+			wantContain: []string{"# Table of Contents", "[guide.md](docs/guide.md)", "> Getting started guide"},
+		},
+		{
+			name:        "read_doc",
+			call:        `{"name":"read_doc","arguments":{"path":"docs/guide.md"}}`,
+			wantContain: []string{"# Guide", "## Install", "## Usage"},
+		},
+		{
+			name:        "read_doc section",
+			call:        `{"name":"read_doc","arguments":{"path":"docs/guide.md","heading":"Install"}}`,
+			wantContain: []string{"## Install", "Run the installer."},
+			wantExclude: []string{"## Usage"},
+		},
+		{
+			name:        "read_doc outside root",
+			call:        `{"name":"read_doc","arguments":{"path":"../secret.md"}}`,
+			wantError:   true,
+			wantContain: []string{"document not found"},
+		},
+		{
+			name:        "search_docs",
+			call:        `{"name":"search_docs","arguments":{"query":"INSTALLER"}}`,
+			wantContain: []string{"docs/guide.md:7: Run the installer."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := serve(t, tmpDir, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+tt.call+`}`)
+
+			result := responses[0]["result"].(map[string]any)
+			if result["isError"] != tt.wantError {
+				t.Errorf("expected isError %v, got %v", tt.wantError, result["isError"])
+			}
+
+			text := result["content"].([]any)[0].(map[string]any)["text"].(string)
+			for _, want := range tt.wantContain {
+				if !strings.Contains(text, want) {
+					t.Errorf("output should contain %q, got:\n%s", want, text)
+				}
+			}
+			for _, unwanted := range tt.wantExclude {
+				if strings.Contains(text, unwanted) {
+					t.Errorf("output should not contain %q, got:\n%s", unwanted, text)
+				}
+			}
+		})
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	responses := serve(t, tmpDir,
+		`not json`,
+		`{"jsonrpc":"2.0","id":2,"method":"unknown/method"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"nope"}}`,
+	)
+
+	expectedCodes := []float64{codeParseError, codeMethodNotFound, codeInvalidParams}
+	for i, code := range expectedCodes {
+		rpcErr, ok := responses[i]["error"].(map[string]any)
+		if !ok {
+			t.Errorf("response %d: expected error, got %v", i, responses[i])
+			continue
+		}
+		if rpcErr["code"] != code {
+			t.Errorf("response %d: expected code %v, got %v", i, code, rpcErr["code"])
+		}
+	}
+}
+
+// Helper functions
+
+func serve(t *testing.T, root string, messages ...string) []map[string]any {
+	t.Helper()
+
+	server := NewServer(Config{
+		Scanner:      scanner.Config{RootPath: root},
+		SummaryChars: 100,
+	})
+
+	var out strings.Builder
+	if err := server.Serve(strings.NewReader(strings.Join(messages, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+
+	var responses []map[string]any
+	lines := bufio.NewScanner(strings.NewReader(out.String()))
+	for lines.Scan() {
+		var resp map[string]any
+		if err := json.Unmarshal(lines.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", lines.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
+func setupTestDir(t *testing.T) string {
+	t.Helper()
+
+	return testutil.TempDir(t, map[string]string{
+		"README.md":     "# README\n\nThis is the main readme file.",
+		"docs/guide.md": "# Guide\n\nGetting started guide for new users.\n\n## Install\n\nRun the installer.\n\n## Usage\n\nRun the tool.",
+	})
+}
@@ -0,0 +1,243 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/danjdewhurst/go-toc/internal/parser"
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/search"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+)
+
+// maxSearchResults caps the number of documents returned by search_docs.
+const maxSearchResults = 50
+
+// tool describes an MCP tool and the handler that implements it.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	handler     func(args json.RawMessage) (string, error)
+}
+
+// docInfo is a single entry returned by list_docs.
+type docInfo struct {
+	Path    string `json:"path"`
+	Title   string `json:"title,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// registerTools returns the tools exposed by the server.
+func (s *Server) registerTools() []tool {
+	return []tool{
+		{
+			Name:        "list_docs",
+			Description: "List all markdown documents with their title and first-paragraph summary.",
+			InputSchema: objectSchema(nil, nil),
+			handler:     s.listDocs,
+		},
+		{
+			Name:        "get_toc",
+			Description: "Get the table of contents for the documentation tree as markdown.",
+			InputSchema: objectSchema(map[string]any{
+				"summary": map[string]any{
+					"type":        "boolean",
+					"description": "Include first-paragraph summaries for each file",
+				},
+			}, nil),
+			handler: s.getTOC,
+		},
+		{
+			Name:        "read_doc",
+			Description: "Read a markdown document, or only the section under a given heading.",
+			InputSchema: objectSchema(map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Path relative to the documentation root, as returned by list_docs",
+				},
+				"heading": map[string]any{
+					"type":        "string",
+					"description": "Optional heading text; only that section is returned",
+				},
+			}, []string{"path"}),
+			handler: s.readDoc,
+		},
+		{
+			Name:        "search_docs",
+			Description: "Search documents ranked by relevance (BM25) and return path:line: snippet results.",
+			InputSchema: objectSchema(map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "Words to search for in text, headings and frontmatter",
+				},
+			}, []string{"query"}),
+			handler: s.searchDocs,
+		},
+	}
+}
+
+// objectSchema builds a JSON schema for a tool's arguments object.
+func objectSchema(properties map[string]any, required []string) map[string]any {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// scan walks the documentation root. Every tool call rescans so clients
+// always see the current state of the files.
+func (s *Server) scan() (*scanner.ScanResult, error) {
+	result, err := scanner.New(s.config.Scanner).ScanWithFiles()
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	return result, nil
+}
+
+// listDocs implements the list_docs tool.
+func (s *Server) listDocs(_ json.RawMessage) (string, error) {
+	result, err := s.scan()
+	if err != nil {
+		return "", err
+	}
+
+	docs := make([]docInfo, 0, len(result.Files))
+	for _, relPath := range result.Files {
+		absPath := filepath.Join(result.RootPath, relPath)
+		info := docInfo{Path: filepath.ToSlash(relPath)}
+
+		if doc, err := parser.ParseDocument(absPath); err == nil {
+			info.Title = doc.Title()
+		}
+		if summary, err := parser.ExtractSummary(absPath, s.config.SummaryChars); err == nil {
+			info.Summary = summary
+		}
+
+		docs = append(docs, info)
+	}
+
+	out, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// getTOC implements the get_toc tool.
+func (s *Server) getTOC(args json.RawMessage) (string, error) {
+	var p struct {
+		Summary bool `json:"summary"`
+	}
+	if err := json.Unmarshal(args, &p); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, err := s.scan()
+	if err != nil {
+		return "", err
+	}
+
+	summaries := make(map[string]string)
+	if p.Summary {
+		for _, relPath := range result.Files {
+			summary, err := parser.ExtractSummary(filepath.Join(result.RootPath, relPath), s.config.SummaryChars)
+			if err == nil && summary != "" {
+				summaries[relPath] = summary
+			}
+		}
+	}
+
+	gen := toc.NewGenerator(toc.GeneratorConfig{
+		Title:          s.config.Title,
+		IncludeSummary: p.Summary,
+		Summaries:      summaries,
+	})
+
+	return gen.Generate(result.Tree), nil
+}
+
+// readDoc implements the read_doc tool.
+func (s *Server) readDoc(args json.RawMessage) (string, error) {
+	var p struct {
+		Path    string `json:"path"`
+		Heading string `json:"heading"`
+	}
+	if err := json.Unmarshal(args, &p); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if p.Path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+
+	result, err := s.scan()
+	if err != nil {
+		return "", err
+	}
+
+	// Only documents found by the scanner may be read, which keeps
+	// clients inside the root and away from ignored files
+	relPath := filepath.Clean(filepath.FromSlash(p.Path))
+	if !slices.Contains(result.Files, relPath) {
+		return "", fmt.Errorf("document not found: %s", p.Path)
+	}
+
+	doc, err := parser.ParseDocument(filepath.Join(result.RootPath, relPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", p.Path, err)
+	}
+
+	if p.Heading == "" {
+		return strings.Join(doc.Lines, "\n"), nil
+	}
+
+	section, ok := doc.Section(p.Heading)
+	if !ok {
+		return "", fmt.Errorf("heading %q not found in %s", p.Heading, p.Path)
+	}
+	return section, nil
+}
+
+// searchDocs implements the search_docs tool.
+func (s *Server) searchDocs(args json.RawMessage) (string, error) {
+	var p struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(args, &p); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if strings.TrimSpace(p.Query) == "" {
+		return "", fmt.Errorf("query is required")
+	}
+
+	result, err := s.scan()
+	if err != nil {
+		return "", err
+	}
+
+	idx, err := search.Build(result.RootPath, result.Files)
+	if err != nil {
+		return "", err
+	}
+
+	results := idx.Search(p.Query, maxSearchResults)
+	if len(results) == 0 {
+		return "No matches found.", nil
+	}
+
+	var sb strings.Builder
+	for _, r := range results {
+		fmt.Fprintf(&sb, "%s:%d: %s\n", filepath.ToSlash(r.Path), r.Line, r.Snippet)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,186 @@
+package parser
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// Heading represents an ATX heading (# Title) within a markdown document.
+type Heading struct {
+	Level int    // Heading level (1-6)
+	Text  string // Heading text with markdown formatting removed
+	Line  int    // 1-based line number in the file
+}
+
+// Document holds the parsed contents of a markdown file.
+type Document struct {
+	Lines       []string    // All lines of the file
+	BodyStart   int         // Index of the first line after the frontmatter
+	Frontmatter Frontmatter // Parsed YAML frontmatter (empty if none)
+	Headings    []Heading   // Headings in document order (code blocks excluded)
+}
+
+// ParseDocument reads a markdown file and indexes its headings.
+// YAML frontmatter and fenced code blocks are never treated as headings.
+func ParseDocument(filePath string) (*Document, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewDocument(lines), nil
+}
+
+// NewDocument builds a Document from the lines of a markdown file.
+func NewDocument(lines []string) *Document {
+	doc := &Document{Lines: lines}
+	doc.BodyStart = frontmatterEnd(lines)
+	if doc.BodyStart > 0 {
+		doc.Frontmatter = parseFrontmatter(lines[1 : doc.BodyStart-1])
+	} else {
+		doc.Frontmatter = make(Frontmatter)
+	}
+
+	inCodeBlock := false
+	for i := doc.BodyStart; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		if level, text, ok := parseHeading(trimmed); ok {
+			doc.Headings = append(doc.Headings, Heading{
+				Level: level,
+				Text:  text,
+				Line:  i + 1,
+			})
+		}
+	}
+
+	return doc
+}
+
+// Title returns the frontmatter title if set, otherwise the text of the
+// first level-1 heading, or an empty string.
+func (d *Document) Title() string {
+	if title := d.Frontmatter.String("title"); title != "" {
+		return title
+	}
+	for _, h := range d.Headings {
+		if h.Level == 1 {
+			return h.Text
+		}
+	}
+	return ""
+}
+
+// Body returns the document content without frontmatter.
+func (d *Document) Body() string {
+	return strings.Join(d.Lines[d.BodyStart:], "\n")
+}
+
+// Prose returns the body text with code blocks removed and markdown
+// formatting stripped, one line per source line.
+func (d *Document) Prose() []string {
+	var lines []string
+	inCodeBlock := false
+
+	for _, line := range d.Lines[d.BodyStart:] {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock || trimmed == "" || strings.HasPrefix(trimmed, "<!--") {
+			continue
+		}
+
+		lines = append(lines, cleanMarkdown(trimmed))
+	}
+
+	return lines
+}
+
+// Section returns the content under the first heading whose text matches
+// heading (case-insensitive), up to the next heading of the same or a
+// higher level. The heading line itself is included.
+func (d *Document) Section(heading string) (string, bool) {
+	for i, h := range d.Headings {
+		if !strings.EqualFold(h.Text, strings.TrimSpace(heading)) {
+			continue
+		}
+
+		end := len(d.Lines)
+		for _, next := range d.Headings[i+1:] {
+			if next.Level <= h.Level {
+				end = next.Line - 1
+				break
+			}
+		}
+
+		section := strings.Join(d.Lines[h.Line-1:end], "\n")
+		return strings.TrimRight(section, "\n"), true
+	}
+
+	return "", false
+}
+
+// frontmatterEnd returns the index of the first line after YAML frontmatter,
+// or 0 if the file does not start with a frontmatter block.
+func frontmatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return 0
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i + 1
+		}
+	}
+
+	// Unterminated frontmatter is treated as regular content
+	return 0
+}
+
+// parseHeading parses an ATX heading line such as "## Setup ##".
+func parseHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+
+	// Strip optional closing sequence of # (must be preceded by a space)
+	text := strings.TrimSpace(rest)
+	if closed := strings.TrimRight(text, "#"); closed == "" || strings.HasSuffix(closed, " ") {
+		text = strings.TrimSpace(closed)
+	}
+
+	return level, cleanMarkdown(text), true
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	content := "---\ntitle: Test\n# not a heading\n---\n\n# Guide\n\nIntro.\n\n```sh\n# comment\n```\n\n## Setup ##\n\nSteps.\n"
+	filePath := filepath.Join(tmpDir, "guide.md")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseDocument(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if doc.BodyStart != 4 {
+		t.Errorf("expected BodyStart 4, got %d", doc.BodyStart)
+	}
+	if len(doc.Headings) != 2 {
+		t.Fatalf("expected 2 headings, got %d: %+v", len(doc.Headings), doc.Headings)
+	}
+
+	expected := []Heading{
+		{Level: 1, Text: "Guide", Line: 6},
+		{Level: 2, Text: "Setup", Line: 14},
+	}
+	for i, want := range expected {
+		if doc.Headings[i] != want {
+			t.Errorf("heading %d: expected %+v, got %+v", i, want, doc.Headings[i])
+		}
+	}
+
+	if doc.Title() != "Test" {
+		t.Errorf("expected frontmatter title 'Test', got '%s'", doc.Title())
+	}
+	if doc.Frontmatter.String("title") != "Test" {
+		t.Errorf("expected frontmatter to be parsed, got %v", doc.Frontmatter)
+	}
+}
+
+func TestDocumentTitleFromHeading(t *testing.T) {
+	doc := NewDocument([]string{"Intro line", "", "# Heading Title", "", "Text."})
+	if doc.Title() != "Heading Title" {
+		t.Errorf("expected title 'Heading Title', got '%s'", doc.Title())
+	}
+}
+
+func TestParseDocumentNonExistentFile(t *testing.T) {
+	_, err := ParseDocument("/nonexistent/file.md")
+	if err == nil {
+		t.Error("expected error for non-existent file")
+	}
+}
+
+func TestParseHeading(t *testing.T) {
+	tests := []struct {
+		line  string
+		level int
+		text  string
+		ok    bool
+	}{
+		{"# Title", 1, "Title", true},
+		{"### **Bold** heading", 3, "Bold heading", true},
+		{"## Closed ##", 2, "Closed", true},
+		{"## C#", 2, "C#", true},
+		{"#hashtag", 0, "", false},
+		{"####### Too deep", 0, "", false},
+		{"plain text", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			level, text, ok := parseHeading(tt.line)
+			if level != tt.level || text != tt.text || ok != tt.ok {
+				t.Errorf("parseHeading(%q) = (%d, %q, %v), expected (%d, %q, %v)",
+					tt.line, level, text, ok, tt.level, tt.text, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDocumentSection(t *testing.T) {
+	doc := NewDocument(strings.Split("# API\n\nOverview.\n\n## Auth\n\nUse tokens.\n\n### Scopes\n\nRead, write.\n\n## Errors\n\nCodes.", "\n"))
+
+	section, ok := doc.Section("auth")
+	if !ok {
+		t.Fatal("expected section to be found")
+	}
+	if !strings.HasPrefix(section, "## Auth") {
+		t.Errorf("section should start with heading, got:\n%s", section)
+	}
+	if !strings.Contains(section, "### Scopes") {
+		t.Error("section should include nested headings")
+	}
+	if strings.Contains(section, "Errors") {
+		t.Error("section should stop at the next sibling heading")
+	}
+
+	if _, ok := doc.Section("missing"); ok {
+		t.Error("expected missing section to return false")
+	}
+}
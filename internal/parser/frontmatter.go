@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Frontmatter holds the key/value pairs of a YAML frontmatter block.
+// Values are either a string or a []string; only the flat subset of YAML
+// used by documentation tools (scalars, block scalars and lists) is
+// supported.
+type Frontmatter map[string]any
+
+// String returns the value for key as a string. Lists are joined with ", ".
+func (f Frontmatter) String(key string) string {
+	switch v := f[key].(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return ""
+}
+
+// Strings returns the value for key as a list. A scalar value is split on
+// commas, so both "tags: a, b" and "tags: [a, b]" yield two entries.
+func (f Frontmatter) Strings(key string) []string {
+	switch v := f[key].(type) {
+	case []string:
+		return v
+	case string:
+		var values []string
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Bool reports whether the value for key is a YAML true value.
+func (f Frontmatter) Bool(key string) bool {
+	switch strings.ToLower(f.String(key)) {
+	case "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// Int returns the value for key as an integer.
+func (f Frontmatter) Int(key string) (int, bool) {
+	n, err := strconv.Atoi(f.String(key))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseFrontmatter parses the lines between the frontmatter delimiters.
+func parseFrontmatter(lines []string) Frontmatter {
+	fm := make(Frontmatter)
+	var listKey string
+	var blockKey, blockStyle string
+	var block []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		// Indented and blank lines continue a block scalar
+		if blockKey != "" {
+			if trimmed == "" || line[0] == ' ' || line[0] == '\t' {
+				block = append(block, line)
+				continue
+			}
+			fm[blockKey] = blockScalar(block, blockStyle)
+			blockKey, block = "", nil
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Block list item belonging to the previous key
+		if listKey != "" && strings.HasPrefix(trimmed, "- ") {
+			items, _ := fm[listKey].([]string)
+			fm[listKey] = append(items, unquote(strings.TrimSpace(trimmed[2:])))
+			continue
+		}
+		listKey = ""
+
+		// Nested mappings are not supported; only top-level keys are read
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case value == "":
+			listKey = key
+			fm[key] = []string{}
+		case isBlockIndicator(value):
+			blockKey, blockStyle = key, value[:1]
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			var items []string
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = unquote(strings.TrimSpace(item)); item != "" {
+					items = append(items, item)
+				}
+			}
+			fm[key] = items
+		default:
+			fm[key] = unquote(value)
+		}
+	}
+	if blockKey != "" {
+		fm[blockKey] = blockScalar(block, blockStyle)
+	}
+
+	return fm
+}
+
+// isBlockIndicator reports whether a value starts a block scalar: "|" or
+// ">" with optional chomping and indentation indicators.
+func isBlockIndicator(value string) bool {
+	if value[0] != '|' && value[0] != '>' {
+		return false
+	}
+	return strings.Trim(value[1:], "+-0123456789") == ""
+}
+
+// blockScalar joins the lines of a block scalar. The literal style ("|")
+// keeps line breaks, while the folded style (">") joins lines with spaces
+// and turns blank lines into breaks. The indentation of the first line is
+// removed from every line, and surrounding blank lines are dropped.
+func blockScalar(lines []string, style string) string {
+	indent := ""
+	for _, line := range lines {
+		if trimmed := strings.TrimLeft(line, " \t"); trimmed != "" {
+			indent = line[:len(line)-len(trimmed)]
+			break
+		}
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		line = strings.TrimRight(strings.TrimPrefix(line, indent), " \t")
+		if i > 0 {
+			switch {
+			case style == "|" || line == "":
+				b.WriteByte('\n')
+			case !strings.HasSuffix(b.String(), "\n"):
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// unquote strips matching single or double quotes from a YAML scalar.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	content := `title: "Deploy Guide"
+description: How we ship
+tags: [ops, 'release']
+categories:
+  - runbooks
+  - infra
+draft: true
+weight: 10
+# a comment
+nested:
+  key: ignored`
+
+	fm := parseFrontmatter(strings.Split(content, "\n"))
+
+	if fm.String("title") != "Deploy Guide" {
+		t.Errorf("expected title 'Deploy Guide', got '%s'", fm.String("title"))
+	}
+	if fm.String("description") != "How we ship" {
+		t.Errorf("expected description 'How we ship', got '%s'", fm.String("description"))
+	}
+	if got := fm.Strings("tags"); !reflect.DeepEqual(got, []string{"ops", "release"}) {
+		t.Errorf("expected inline list, got %v", got)
+	}
+	if got := fm.Strings("categories"); !reflect.DeepEqual(got, []string{"runbooks", "infra"}) {
+		t.Errorf("expected block list, got %v", got)
+	}
+	if !fm.Bool("draft") {
+		t.Error("expected draft to be true")
+	}
+	if n, ok := fm.Int("weight"); !ok || n != 10 {
+		t.Errorf("expected weight 10, got %d (%v)", n, ok)
+	}
+	if _, ok := fm["key"]; ok {
+		t.Error("nested keys should not be read as top-level keys")
+	}
+}
+
+func TestParseFrontmatterBlockScalars(t *testing.T) {
+	content := `description: >
+  Deploying the API
+  to production.
+
+  Rollbacks are covered too.
+notes: |-
+  First line
+    indented line
+title: Deploy`
+
+	fm := parseFrontmatter(strings.Split(content, "\n"))
+
+	expected := "Deploying the API to production.\nRollbacks are covered too."
+	if got := fm.String("description"); got != expected {
+		t.Errorf("expected folded %q, got %q", expected, got)
+	}
+	expected = "First line\n  indented line"
+	if got := fm.String("notes"); got != expected {
+		t.Errorf("expected literal %q, got %q", expected, got)
+	}
+	if got := fm.String("title"); got != "Deploy" {
+		t.Errorf("expected title after block scalar, got %q", got)
+	}
+}
+
+func TestFrontmatterStringsFromScalar(t *testing.T) {
+	fm := Frontmatter{"tags": "api, auth ,"}
+	if got := fm.Strings("tags"); !reflect.DeepEqual(got, []string{"api", "auth"}) {
+		t.Errorf("expected comma-separated scalar to split, got %v", got)
+	}
+	if fm.Strings("missing") != nil {
+		t.Error("expected nil for missing key")
+	}
+}
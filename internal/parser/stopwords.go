@@ -0,0 +1,50 @@
+package parser
+
+import "strings"
+
+// stopwords are common words that carry little meaning on their own and are
+// ignored when comparing, ranking or extracting keywords from text. Lists
+// cover English, German, French and Spanish, since a docs tree often mixes
+// languages.
+var stopwords = toSet(
+	// English
+	`a about above after again against all also am an and any are as at
+	be because been before being below between both but by can could did do does doing down during
+	each few for from further had has have having he her here hers him his how if in into is it its
+	itself just me more most my no nor not now of off on once only or other our ours out over own
+	same she should so some such than that the their theirs them then there these they this those
+	through to too under until up very was we were what when where which while who whom why will
+	with would you your yours`,
+	// German
+	`aber alle allem allen aller alles als also am an ander andere anderen auch auf aus bei bin bis
+	bist da damit dann das dass dein deine dem den der des dich die dies diese diesem diesen dieser
+	dieses dir doch dort du durch ein eine einem einen einer eines er es etwas euch euer für hab habe
+	haben hat hatte hier hin hinter ich ihm ihn ihr ihre im in ist jede jedem jeden jeder jedes jetzt
+	kann kein keine können man mein meine mich mir mit muss nach nicht nichts noch nun nur ob oder
+	ohne sehr sein seine sich sie sind so solche soll sondern über um und uns unser unter viel vom
+	von vor war waren was weil welche wenn wer werde werden wie wieder will wir wird wo zu zum zur`,
+	// French
+	`au aux avec ce ces cette dans de des du elle en et eux il ils je la le les leur lui ma mais me
+	même mes moi mon ne nos notre nous on ou par pas pour qu que qui sa se ses son sur ta te tes toi
+	ton tu un une vos votre vous est sont été être avoir ont fait plus tout tous`,
+	// Spanish
+	`al algo como con cual cuando de del desde donde el ella ellas ellos en entre era es esa ese eso
+	esta este esto estos fue ha hay la las le les lo los más me mi muy no nos o para pero por que
+	qué se ser si sin sobre son su sus también te tiene todo todos tu un una uno y ya`,
+)
+
+// IsStopword reports whether word (lowercase) is a stopword.
+func IsStopword(word string) bool {
+	return stopwords[word]
+}
+
+// toSet builds a lookup set from whitespace-separated word lists.
+func toSet(lists ...string) map[string]bool {
+	set := make(map[string]bool)
+	for _, list := range lists {
+		for _, w := range strings.Fields(list) {
+			set[w] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,350 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SummaryMode selects the strategy used to summarize a document.
+type SummaryMode string
+
+const (
+	SummaryParagraph   SummaryMode = "paragraph"   // First paragraph (default)
+	SummaryDescription SummaryMode = "description" // Frontmatter description, falling back to first paragraph
+	SummarySentence    SummaryMode = "sentence"    // First sentence of the first paragraph
+	SummaryTextRank    SummaryMode = "textrank"    // Most representative sentences chosen by TextRank
+)
+
+// SummaryModes lists all supported summary modes.
+var SummaryModes = []SummaryMode{SummaryParagraph, SummaryDescription, SummarySentence, SummaryTextRank}
+
+// TextRank parameters.
+const (
+	textRankDamping    = 0.85
+	textRankIterations = 30
+	textRankMinWords   = 4 // Shorter fragments (badges, labels) are not candidates
+)
+
+// descriptionKeys are the frontmatter keys checked by SummaryDescription, in order.
+var descriptionKeys = []string{"description", "summary", "excerpt"}
+
+// ParseSummaryMode validates a summary mode name. An empty name selects
+// SummaryParagraph.
+func ParseSummaryMode(name string) (SummaryMode, error) {
+	if name == "" {
+		return SummaryParagraph, nil
+	}
+	for _, mode := range SummaryModes {
+		if string(mode) == name {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown summary mode %q (expected paragraph, description, sentence or textrank)", name)
+}
+
+// ExtractSummaryWithMode summarizes a markdown file using the given mode.
+// Returns an empty string if no suitable content is found.
+func ExtractSummaryWithMode(filePath string, maxChars int, mode SummaryMode) (string, error) {
+	if mode == SummaryParagraph || mode == "" {
+		return ExtractSummary(filePath, maxChars)
+	}
+
+	doc, err := ParseDocument(filePath)
+	if err != nil {
+		return "", err
+	}
+	return doc.Summary(maxChars, mode), nil
+}
+
+// Summary summarizes the document using the given mode.
+func (d *Document) Summary(maxChars int, mode SummaryMode) string {
+	paragraphs := d.Paragraphs()
+
+	var summary string
+	switch mode {
+	case SummaryDescription:
+		for _, key := range descriptionKeys {
+			if summary = cleanMarkdown(d.Frontmatter.String(key)); summary != "" {
+				break
+			}
+		}
+		if summary == "" {
+			summary = firstSubstantial(paragraphs)
+		}
+	case SummarySentence:
+		summary = splitSentences(firstSubstantial(paragraphs))[0]
+	case SummaryTextRank:
+		summary = textRank(paragraphs, maxChars)
+	default:
+		if len(paragraphs) > 0 {
+			summary = paragraphs[0]
+		}
+	}
+
+	return truncate(summary, maxChars)
+}
+
+// Paragraphs returns the cleaned text of each body paragraph, skipping the
+// same content as ExtractSummary: headings, code blocks, HTML comments,
+// horizontal rules, list items and blockquotes.
+func (d *Document) Paragraphs() []string {
+	var paragraphs []string
+	var current []string
+	inCodeBlock := false
+
+	flush := func() {
+		if len(current) > 0 {
+			if text := cleanMarkdown(strings.Join(current, " ")); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+			current = nil
+		}
+	}
+
+	for _, line := range d.Lines[d.BodyStart:] {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "#"),
+			strings.HasPrefix(trimmed, "<!--"),
+			isHorizontalRule(trimmed),
+			strings.HasPrefix(trimmed, "-"),
+			strings.HasPrefix(trimmed, "*"),
+			strings.HasPrefix(trimmed, ">"):
+			flush()
+		default:
+			current = append(current, trimmed)
+		}
+	}
+	flush()
+
+	return paragraphs
+}
+
+// firstSubstantial returns the first paragraph with at least
+// textRankMinWords words, skipping badge lines, lone links and similar
+// fragments. Falls back to the first paragraph when none qualifies.
+func firstSubstantial(paragraphs []string) string {
+	for _, paragraph := range paragraphs {
+		if len(strings.Fields(paragraph)) >= textRankMinWords {
+			return paragraph
+		}
+	}
+	if len(paragraphs) > 0 {
+		return paragraphs[0]
+	}
+	return ""
+}
+
+// splitSentences splits text into sentences at ., ! or ? followed by
+// whitespace and an uppercase letter or digit. Always returns at least one
+// element.
+func splitSentences(text string) []string {
+	runes := []rune(text)
+	var sentences []string
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '.' && runes[i] != '!' && runes[i] != '?' {
+			continue
+		}
+		if i+2 >= len(runes) || !unicode.IsSpace(runes[i+1]) {
+			continue
+		}
+
+		next := runes[i+2]
+		if !unicode.IsUpper(next) && !unicode.IsDigit(next) {
+			continue
+		}
+
+		// Avoid splitting after common abbreviations like "e.g." or "Dr."
+		word := lastWord(runes[start : i+1])
+		if isAbbreviation(word) {
+			continue
+		}
+
+		sentences = append(sentences, strings.TrimSpace(string(runes[start:i+1])))
+		start = i + 2
+	}
+
+	if rest := strings.TrimSpace(string(runes[start:])); rest != "" || len(sentences) == 0 {
+		sentences = append(sentences, rest)
+	}
+
+	return sentences
+}
+
+// lastWord returns the final whitespace-separated word of runes.
+func lastWord(runes []rune) string {
+	i := len(runes)
+	for i > 0 && !unicode.IsSpace(runes[i-1]) {
+		i--
+	}
+	return strings.ToLower(string(runes[i:]))
+}
+
+// isAbbreviation reports whether word is a common abbreviation ending in a period.
+func isAbbreviation(word string) bool {
+	switch word {
+	case "e.g.", "i.e.", "etc.", "vs.", "mr.", "mrs.", "ms.", "dr.", "st.", "no.", "cf.", "approx.":
+		return true
+	}
+	return false
+}
+
+// textRank ranks all sentences of the paragraphs by centrality and returns
+// the best ones that fit within maxChars, in their original order.
+func textRank(paragraphs []string, maxChars int) string {
+	var sentences []string
+	var terms [][]string
+	for _, paragraph := range paragraphs {
+		for _, sentence := range splitSentences(paragraph) {
+			words := contentWords(sentence)
+			if len(strings.Fields(sentence)) < textRankMinWords || len(words) == 0 {
+				continue
+			}
+			sentences = append(sentences, sentence)
+			terms = append(terms, words)
+		}
+	}
+
+	if len(sentences) == 0 {
+		// Nothing substantial to rank; fall back to the first paragraph
+		if len(paragraphs) > 0 {
+			return paragraphs[0]
+		}
+		return ""
+	}
+
+	scores := pageRank(similarityMatrix(terms))
+
+	order := make([]int, len(sentences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	// Greedily take the highest ranked sentences that fit
+	var chosen []int
+	length := 0
+	for _, i := range order {
+		added := len(sentences[i])
+		if len(chosen) > 0 {
+			added++
+		}
+		if len(chosen) > 0 && maxChars > 0 && length+added > maxChars {
+			continue
+		}
+		chosen = append(chosen, i)
+		length += added
+		if maxChars <= 0 {
+			break
+		}
+	}
+	sort.Ints(chosen)
+
+	parts := make([]string, len(chosen))
+	for i, idx := range chosen {
+		parts[i] = sentences[idx]
+	}
+	return strings.Join(parts, " ")
+}
+
+// similarityMatrix computes the TextRank sentence similarity: shared words
+// normalized by the log of both sentence lengths.
+func similarityMatrix(terms [][]string) [][]float64 {
+	sets := make([]map[string]bool, len(terms))
+	for i, words := range terms {
+		sets[i] = make(map[string]bool, len(words))
+		for _, w := range words {
+			sets[i][w] = true
+		}
+	}
+
+	matrix := make([][]float64, len(terms))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(terms))
+	}
+
+	for i := range terms {
+		for j := i + 1; j < len(terms); j++ {
+			overlap := 0
+			for w := range sets[i] {
+				if sets[j][w] {
+					overlap++
+				}
+			}
+			if overlap == 0 {
+				continue
+			}
+			denom := math.Log(float64(len(sets[i])+1)) + math.Log(float64(len(sets[j])+1))
+			matrix[i][j] = float64(overlap) / denom
+			matrix[j][i] = matrix[i][j]
+		}
+	}
+
+	return matrix
+}
+
+// pageRank runs weighted PageRank over a similarity matrix.
+func pageRank(matrix [][]float64) []float64 {
+	n := len(matrix)
+	scores := make([]float64, n)
+	for i := range scores {
+		scores[i] = 1.0 / float64(n)
+	}
+
+	outWeight := make([]float64, n)
+	for i, row := range matrix {
+		for _, w := range row {
+			outWeight[i] += w
+		}
+	}
+
+	for iter := 0; iter < textRankIterations; iter++ {
+		next := make([]float64, n)
+		for i := range next {
+			sum := 0.0
+			for j := range matrix {
+				if matrix[j][i] > 0 && outWeight[j] > 0 {
+					sum += matrix[j][i] / outWeight[j] * scores[j]
+				}
+			}
+			next[i] = (1-textRankDamping)/float64(n) + textRankDamping*sum
+		}
+		scores = next
+	}
+
+	return scores
+}
+
+// contentWords returns the lowercase words of text with stopwords removed.
+func contentWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	words := fields[:0]
+	for _, field := range fields {
+		if len([]rune(field)) > 1 && !IsStopword(field) {
+			words = append(words, field)
+		}
+	}
+	return words
+}
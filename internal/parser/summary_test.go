@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSummaryMode(t *testing.T) {
+	for _, name := range []string{"", "paragraph", "description", "sentence", "textrank"} {
+		if _, err := ParseSummaryMode(name); err != nil {
+			t.Errorf("ParseSummaryMode(%q) returned error: %v", name, err)
+		}
+	}
+	if _, err := ParseSummaryMode("llm"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestExtractSummaryWithMode(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name     string
+		content  string
+		mode     SummaryMode
+		maxChars int
+		expected string
+	}{
+		{
+			name:     "paragraph",
+			content:  "# Title\n\nFirst sentence here. Second sentence here.",
+			mode:     SummaryParagraph,
+			maxChars: 100,
+			expected: "First sentence here. Second sentence here.",
+		},
+		{
+			name:     "description from frontmatter",
+			content:  "---\ndescription: \"Deploying the **API** service\"\n---\n\n# Title\n\nBody text.",
+			mode:     SummaryDescription,
+			maxChars: 100,
+			expected: "Deploying the API service",
+		},
+		{
+			name:     "description falls back to paragraph",
+			content:  "# Title\n\nBody text.",
+			mode:     SummaryDescription,
+			maxChars: 100,
+			expected: "Body text.",
+		},
+		{
+			name:     "description fallback skips badges",
+			content:  "# Title\n\n[![Build](b.svg)](ci) [![Cov](c.svg)](cov)\n\nDeploys the API service. More text.",
+			mode:     SummaryDescription,
+			maxChars: 100,
+			expected: "Deploys the API service. More text.",
+		},
+		{
+			name:     "first sentence skips badges",
+			content:  "# Title\n\n[![Build](b.svg)](ci) [![Cov](c.svg)](cov)\n\n[Docs](docs.md)\n\nDeploys the API service. More text.",
+			mode:     SummarySentence,
+			maxChars: 100,
+			expected: "Deploys the API service.",
+		},
+		{
+			name:     "first sentence",
+			content:  "# Title\n\nUse e.g. the CLI to deploy. Then verify it works.",
+			mode:     SummarySentence,
+			maxChars: 100,
+			expected: "Use e.g. the CLI to deploy.",
+		},
+		{
+			name: "textrank skips badges and boilerplate",
+			content: "# Title\n\n[![Build](b.svg)](ci)\n\n" +
+				"This document describes things.\n\n" +
+				"The cache server stores build artifacts for the build farm. " +
+				"Clients upload build artifacts after each build. " +
+				"Our office has a nice view.",
+			mode:     SummaryTextRank,
+			maxChars: 60,
+			expected: "The cache server stores build artifacts for the build farm.",
+		},
+		{
+			name:     "textrank with no sentences",
+			content:  "# Title\n\nShort.",
+			mode:     SummaryTextRank,
+			maxChars: 100,
+			expected: "Short.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tmpDir, tt.name+".md")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ExtractSummaryWithMode(filePath, tt.maxChars, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"One. Two! Three?", []string{"One.", "Two!", "Three?"}},
+		{"Version 1.2 is out. See docs.", []string{"Version 1.2 is out.", "See docs."}},
+		{"Ask Dr. Smith first.", []string{"Ask Dr. Smith first."}},
+		{"no punctuation", []string{"no punctuation"}},
+		{"", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result := splitSentences(tt.text)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("splitSentences(%q) = %q, expected %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDocumentParagraphs(t *testing.T) {
+	doc := NewDocument(strings.Split("# Title\n\nFirst\nline.\n\n- item\n\n```\ncode\n```\n\n> quote\n\nSecond.", "\n"))
+
+	expected := []string{"First line.", "Second."}
+	if result := doc.Paragraphs(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
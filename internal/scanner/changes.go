@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/danjdewhurst/go-toc/internal/gitinfo"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+)
+
+// applyChanges restricts a scanned tree to the markdown files changed since
+// the configured revision and marks each with its change. Deleted files
+// are added back so they can be listed, unless they would have been
+// ignored. It returns the files that remain.
+func (s *Scanner) applyChanges(tree *toc.Tree, files []string) ([]string, error) {
+	changes, err := gitinfo.Changes(s.config.RootPath, s.config.ChangedSince)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]string, 0, len(files))
+	for _, relPath := range files {
+		change, ok := changes[filepath.ToSlash(relPath)]
+		if !ok {
+			continue
+		}
+		if node := tree.Find(relPath); node != nil {
+			node.Change = string(change)
+		}
+		changed = append(changed, relPath)
+	}
+
+	tree.Exclude(func(node *toc.Node) bool {
+		return node.Change == ""
+	})
+
+	for relPath, change := range changes {
+		if change == gitinfo.Deleted && isMarkdownFile(relPath) && !s.ignoresDeleted(relPath) {
+			tree.AddFile(relPath).Change = toc.ChangeDeleted
+		}
+	}
+
+	return changed, nil
+}
+
+// ignoresDeleted reports whether a deleted file, or any directory above
+// it, would be skipped by a scan or lies deeper than the maximum depth.
+func (s *Scanner) ignoresDeleted(relPath string) bool {
+	parts := strings.Split(relPath, "/")
+	if s.config.MaxDepth > 0 && len(parts) > s.config.MaxDepth {
+		return true
+	}
+
+	for i := range parts {
+		current := filepath.FromSlash(path.Join(parts[:i+1]...))
+		if s.shouldIgnore(current, i < len(parts)-1) {
+			return true
+		}
+	}
+	return false
+}
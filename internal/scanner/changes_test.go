@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+)
+
+func TestScannerChangedSince(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "README.md", "# README")
+	createTestFile(t, tmpDir, "docs/guide.md", "# Guide")
+	createTestFile(t, tmpDir, "docs/old.md", "# Old")
+	createTestFile(t, tmpDir, "legacy/gone.md", "# Gone")
+	createTestFile(t, tmpDir, "vendor/lib.md", "# Vendor")
+	testutil.Git(t, tmpDir, "init", "-q")
+	testutil.Git(t, tmpDir, "add", "-A")
+	testutil.Git(t, tmpDir, "commit", "-q", "-m", "v1")
+	testutil.Git(t, tmpDir, "tag", "v1")
+
+	createTestFile(t, tmpDir, "docs/guide.md", "# Guide\n\nUpdated.")
+	createTestFile(t, tmpDir, "docs/new.md", "# New")
+	createTestFile(t, tmpDir, "notes.txt", "not markdown")
+	testutil.Git(t, tmpDir, "rm", "-q", "docs/old.md", "legacy/gone.md", "vendor/lib.md")
+	testutil.Git(t, tmpDir, "add", "-A")
+	testutil.Git(t, tmpDir, "commit", "-q", "-m", "v2")
+
+	// Uncommitted and untracked files count too
+	createTestFile(t, tmpDir, "README.md", "# README\n\nEdited.")
+	createTestFile(t, tmpDir, "docs/draft.md", "# Draft")
+
+	s := New(Config{RootPath: tmpDir, ChangedSince: "v1", IgnorePatterns: []string{"vendor"}})
+	result, err := s.ScanWithFiles()
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"README.md":      toc.ChangeModified,
+		"docs/guide.md":  toc.ChangeModified,
+		"docs/new.md":    toc.ChangeAdded,
+		"docs/draft.md":  toc.ChangeAdded,
+		"docs/old.md":    toc.ChangeDeleted,
+		"legacy/gone.md": toc.ChangeDeleted,
+	}
+	var found []string
+	result.Tree.Walk(func(node *toc.Node, depth int, isLast bool) {
+		if node.IsDir {
+			return
+		}
+		found = append(found, node.Path)
+		if node.Change != expected[node.Path] {
+			t.Errorf("%s: expected change %q, got %q", node.Path, expected[node.Path], node.Change)
+		}
+	})
+	if len(found) != len(expected) {
+		t.Errorf("expected %d files, got %v", len(expected), found)
+	}
+
+	// Deleted files cannot be read, so they are not returned as files
+	if files := strings.Join(result.Files, " "); strings.Contains(files, "old.md") || len(result.Files) != 4 {
+		t.Errorf("expected only existing changed files, got %q", files)
+	}
+
+	s = New(Config{RootPath: tmpDir, ChangedSince: "no-such-ref"})
+	if _, err := s.ScanWithFiles(); err == nil || !strings.Contains(err.Error(), "unknown revision") {
+		t.Errorf("expected unknown revision error, got %v", err)
+	}
+}
@@ -2,17 +2,26 @@ package scanner
 
 import (
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
-	ignore "github.com/sabhiram/go-gitignore"
+	"github.com/danjdewhurst/go-toc/internal/gitinfo"
 )
 
-// GitignoreManager handles parsing and matching of .gitignore patterns.
+// GitignoreManager decides which paths git would ignore. Patterns come
+// from core.excludesFile, .git/info/exclude and every .gitignore from the
+// top of the working copy down to the path, in increasing precedence; the
+// last matching pattern wins. As in git, a path inside an ignored
+// directory stays ignored even if a pattern would re-include it.
 type GitignoreManager struct {
-	rootPath string
-	matchers map[string]*ignore.GitIgnore // Map of directory path to matcher
-	errors   []GitignoreError             // Collected errors from gitignore parsing
+	rootPath string                 // Scanned root directory
+	repoRoot string                 // Top of the working copy containing rootPath, or rootPath
+	prefix   string                 // rootPath relative to repoRoot, slash-separated ("" if the same)
+	excludes []*ignoreFile          // Global and repository excludes, lowest precedence first
+	matchers map[string]*ignoreFile // Map of directory path to its .gitignore
+	checked  map[string]bool        // Directories already looked at for a .gitignore
+	errors   []GitignoreError       // Collected errors from gitignore parsing
 }
 
 // GitignoreError represents an error encountered while parsing a .gitignore file.
@@ -25,12 +34,27 @@ type GitignoreError struct {
 func NewGitignoreManager(rootPath string) *GitignoreManager {
 	mgr := &GitignoreManager{
 		rootPath: rootPath,
-		matchers: make(map[string]*ignore.GitIgnore),
+		repoRoot: rootPath,
+		matchers: make(map[string]*ignoreFile),
+		checked:  make(map[string]bool),
 		errors:   make([]GitignoreError, 0),
 	}
 
+	// Excludes only apply inside a repository; elsewhere the root's
+	// .gitignore files are still honoured
+	if repoRoot, gitDir, ok := findRepository(rootPath); ok {
+		if rel, err := filepath.Rel(repoRoot, rootPath); err == nil && rel != "." {
+			mgr.repoRoot = repoRoot
+			mgr.prefix = filepath.ToSlash(rel)
+		}
+		if excludesFile := globalExcludesFile(repoRoot); excludesFile != "" {
+			mgr.loadExcludes(excludesFile)
+		}
+		mgr.loadExcludes(filepath.Join(gitDir, "info", "exclude"))
+	}
+
 	// Load root .gitignore
-	mgr.loadGitignore(rootPath)
+	mgr.LoadGitignoreForDir(rootPath)
 
 	return mgr
 }
@@ -40,65 +64,70 @@ func (m *GitignoreManager) Errors() []GitignoreError {
 	return m.errors
 }
 
+// readIgnoreFile reads an ignore file, recording any error other than the
+// file not existing.
+func (m *GitignoreManager) readIgnoreFile(filename, base string) *ignoreFile {
+	ignore, err := readIgnoreFile(filename, base)
+	if err != nil {
+		// Record the error for later reporting
+		m.errors = append(m.errors, GitignoreError{Path: filename, Err: err})
+	}
+	return ignore
+}
+
+// loadExcludes adds an exclude file whose patterns are relative to the
+// top of the working copy.
+func (m *GitignoreManager) loadExcludes(filename string) {
+	if ignore := m.readIgnoreFile(filename, ""); ignore != nil {
+		m.excludes = append(m.excludes, ignore)
+	}
+}
+
 // loadGitignore loads a .gitignore file from the specified directory.
 func (m *GitignoreManager) loadGitignore(dirPath string) {
-	gitignorePath := filepath.Join(dirPath, ".gitignore")
-
-	if _, err := os.Stat(gitignorePath); err == nil {
-		matcher, err := ignore.CompileIgnoreFile(gitignorePath)
-		if err != nil {
-			// Record the error for later reporting
-			m.errors = append(m.errors, GitignoreError{
-				Path: gitignorePath,
-				Err:  err,
-			})
-			return
-		}
-		m.matchers[dirPath] = matcher
+	m.checked[dirPath] = true
+
+	base, err := filepath.Rel(m.repoRoot, dirPath)
+	if err != nil {
+		return
+	}
+	base = filepath.ToSlash(base)
+	if base == "." {
+		base = ""
+	}
+
+	if ignore := m.readIgnoreFile(filepath.Join(dirPath, ".gitignore"), base); ignore != nil {
+		m.matchers[dirPath] = ignore
 	}
 }
 
-// IsIgnored checks if a path should be ignored based on gitignore patterns.
-func (m *GitignoreManager) IsIgnored(relPath string, isDir bool) bool {
-	if len(m.matchers) == 0 {
-		return false
+// gitignoreFor returns the .gitignore of a directory relative to the top
+// of the working copy, loading it on first use.
+func (m *GitignoreManager) gitignoreFor(dir string) *ignoreFile {
+	dirPath := filepath.Join(m.repoRoot, filepath.FromSlash(dir))
+	if !m.checked[dirPath] {
+		m.loadGitignore(dirPath)
 	}
+	return m.matchers[dirPath]
+}
 
+// IsIgnored checks if a path should be ignored based on gitignore patterns.
+func (m *GitignoreManager) IsIgnored(relPath string, isDir bool) bool {
 	// Convert to forward slashes for consistent matching
 	normalizedPath := filepath.ToSlash(relPath)
-
-	// Add trailing slash for directories (gitignore convention)
-	pathToCheck := normalizedPath
-	if isDir {
-		pathToCheck = normalizedPath + "/"
+	if normalizedPath == "" || normalizedPath == "." {
+		return false
 	}
 
-	// Check against all loaded gitignore files
-	// We need to check parent directories' gitignore files as well
-	for matcherDir, matcher := range m.matchers {
-		// Get relative path from matcher directory
-		matcherRelPath, err := filepath.Rel(m.rootPath, matcherDir)
-		if err != nil {
-			continue
-		}
-
-		// Determine the path relative to this gitignore's location
-		var relToMatcher string
-		if matcherRelPath == "." {
-			relToMatcher = pathToCheck
-		} else {
-			matcherRelNorm := filepath.ToSlash(matcherRelPath)
-			if strings.HasPrefix(normalizedPath, matcherRelNorm+"/") {
-				relToMatcher = strings.TrimPrefix(normalizedPath, matcherRelNorm+"/")
-				if isDir {
-					relToMatcher += "/"
-				}
-			} else {
-				continue // Path is not under this gitignore's directory
-			}
-		}
-
-		if matcher.MatchesPath(relToMatcher) {
+	// Check every directory on the way down first, since nothing inside an
+	// ignored directory can be re-included
+	parts := strings.Split(path.Join(m.prefix, normalizedPath), "/")
+	start := 0
+	if m.prefix != "" {
+		start = strings.Count(m.prefix, "/") + 1
+	}
+	for i := start; i < len(parts); i++ {
+		if m.matches(parts[:i+1], i < len(parts)-1 || isDir) {
 			return true
 		}
 	}
@@ -106,6 +135,23 @@ func (m *GitignoreManager) IsIgnored(relPath string, isDir bool) bool {
 	return false
 }
 
+// matches applies all patterns to a path, given as its components
+// relative to the top of the working copy. The last matching pattern
+// decides, so sources are visited from lowest to highest precedence.
+func (m *GitignoreManager) matches(parts []string, isDir bool) bool {
+	fullPath := strings.Join(parts, "/")
+	ignored := false
+
+	for _, ignore := range m.excludes {
+		ignored = ignore.apply(fullPath, isDir, ignored)
+	}
+	for i := 0; i < len(parts); i++ {
+		ignored = m.gitignoreFor(strings.Join(parts[:i], "/")).apply(fullPath, isDir, ignored)
+	}
+
+	return ignored
+}
+
 // LoadGitignoreForDir loads the .gitignore file from a specific directory.
 // Call this as you traverse directories to pick up nested .gitignore files.
 func (m *GitignoreManager) LoadGitignoreForDir(dirPath string) {
@@ -113,3 +159,70 @@ func (m *GitignoreManager) LoadGitignoreForDir(dirPath string) {
 		m.loadGitignore(dirPath)
 	}
 }
+
+// findRepository looks for the working copy containing dir and returns its
+// top directory and the git directory holding its info/exclude file.
+func findRepository(dir string) (repoRoot, gitDir string, ok bool) {
+	for current := dir; ; {
+		dotGit := filepath.Join(current, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return current, dotGit, true
+			}
+			// Worktrees and submodules have a .git file pointing elsewhere
+			if gitDir := readGitFile(dotGit); gitDir != "" {
+				return current, gitDir, true
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", "", false
+		}
+		current = parent
+	}
+}
+
+// readGitFile resolves a "gitdir: <path>" .git file to the common git
+// directory, which is where linked worktrees share info/exclude.
+func readGitFile(filename string) string {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(filename), gitDir)
+	}
+
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		return commonDir
+	}
+	return gitDir
+}
+
+// globalExcludesFile returns the core.excludesFile git uses for the
+// repository at repoRoot, as reported by git config. When it is unset or
+// git is not installed, git's default of $XDG_CONFIG_HOME/git/ignore is
+// used.
+func globalExcludesFile(repoRoot string) string {
+	if excludesFile, err := gitinfo.ExcludesFile(repoRoot); err == nil && excludesFile != "" {
+		return excludesFile
+	}
+
+	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+		return filepath.Join(xdgConfig, "git", "ignore")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+	return ""
+}
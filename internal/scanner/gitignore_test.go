@@ -2,7 +2,9 @@ package scanner
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -182,3 +184,208 @@ func TestGitignoreManagerLoadGitignoreForDir(t *testing.T) {
 		t.Error("loading same dir twice shouldn't add duplicate matcher")
 	}
 }
+
+func TestParseIgnorePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.log", "a/b/error.log", false, true},
+		{"secret?.md", "secret1.md", false, true},
+		{"secret?.md", "secret12.md", false, false},
+		{"[Tt]emp*.md", "temp-notes.md", false, true},
+		{"[!a-c]x.md", "bx.md", false, false},
+		{"[!a-c]x.md", "dx.md", false, true},
+		{"[[:digit:]]*.md", "1-intro.md", false, true},
+		{"[]]x", "]x", false, true},
+		{"/root.md", "root.md", false, true},
+		{"/root.md", "sub/root.md", false, false},
+		{"docs/*.tmp", "docs/a.tmp", false, true},
+		{"docs/*.tmp", "docs/sub/a.tmp", false, false},
+		{"a/**/z.md", "a/z.md", false, true},
+		{"a/**/z.md", "a/b/c/z.md", false, true},
+		{"**/cache", "x/y/cache", true, true},
+		{"logs/**", "logs/a/b.md", false, true},
+		{"build/", "build", false, false},
+		{"build/", "build", true, true},
+		{"foo**bar", "fooxbar", false, true},
+		{"foo**bar", "foo/bar", false, false},
+		{`\#hash.md`, "#hash.md", false, true},
+		{`\!bang.md`, "!bang.md", false, true},
+		{`trailing\ `, "trailing ", false, true},
+		{"spaces   ", "spaces", false, true},
+	}
+
+	for _, tt := range tests {
+		p, ok := parseIgnorePattern(tt.pattern)
+		if !ok {
+			t.Errorf("parseIgnorePattern(%q) failed", tt.pattern)
+			continue
+		}
+		if got := p.match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("pattern %q on %q (dir %v): expected %v, got %v", tt.pattern, tt.path, tt.isDir, tt.want, got)
+		}
+	}
+
+	for _, line := range []string{"", "   ", "# comment", "!", "/"} {
+		if _, ok := parseIgnorePattern(line); ok {
+			t.Errorf("parseIgnorePattern(%q) should be skipped", line)
+		}
+	}
+}
+
+func TestGitignoreManagerPrecedence(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-gitignore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, ".gitignore", "*.draft.md\nvendor/\n!vendor/keep.md\n")
+	createTestFile(t, tmpDir, "docs/.gitignore", "!keep.draft.md\n")
+	createTestFile(t, tmpDir, "docs/deep/.gitignore", "keep.draft.md\n")
+
+	mgr := NewGitignoreManager(tmpDir)
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"plan.draft.md", true},
+		{"docs/keep.draft.md", false},       // Deeper negation overrides the root
+		{"docs/deep/keep.draft.md", true},   // Deepest file has the last word
+		{"docs/other/keep.draft.md", false}, // Unaffected by a sibling's file
+		{"vendor/keep.md", true},            // Cannot re-include inside an ignored directory
+	}
+
+	for _, tt := range tests {
+		if result := mgr.IsIgnored(tt.path, false); result != tt.expected {
+			t.Errorf("IsIgnored(%q): expected %v, got %v", tt.path, tt.expected, result)
+		}
+	}
+}
+
+// TestGitignoreManagerMatchesGit compares IsIgnored with git check-ignore
+// on a repository exercising every ignore source and precedence rule.
+func TestGitignoreManagerMatchesGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "go-toc-gitignore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	repo := filepath.Join(tmpDir, "repo")
+	home := filepath.Join(tmpDir, "home")
+	createTestFile(t, home, ".gitconfig", "[core]\n\texcludesFile = ~/global-ignore\n")
+	createTestFile(t, home, "global-ignore", "global-ignored.md\n*.bak.md\n")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, ".gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	git := func(stdin string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Stdin = strings.NewReader(stdin)
+		out, err := cmd.Output()
+		// check-ignore exits with 1 when nothing is ignored
+		if exitErr, ok := err.(*exec.ExitError); err != nil && !(ok && exitErr.ExitCode() == 1) {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+		return string(out)
+	}
+
+	createTestDir(t, repo, "")
+	git("", "init", "-q")
+	createTestFile(t, repo, ".git/info/exclude", "excluded-by-info.md\n!info-keep.log\n")
+	createTestFile(t, repo, ".gitignore", `# Root rules
+*.log
+!keep.log
+/build/
+docs/*.tmp
+**/cache/
+secret?.md
+[Tt]emp*.md
+logs/**
+!logs/README.md
+a/**/z.md
+\#hash.md
+\!bang.md
+vendor
+!vendor/keep.md
+*.draft.md
+!restored.bak.md
+`)
+	createTestFile(t, repo, "nested/.gitignore", "!important.draft.md\nlocal.md\n/anchored.md\nsub/\n")
+	createTestFile(t, repo, "nested/deeper/.gitignore", "!local.md\n*.md\n!keep*.md\n")
+
+	files := []string{
+		"README.md", "error.log", "keep.log", "info-keep.log",
+		"build/out.md", "src/build/out.md", "docs/x.tmp", "docs/sub/x.tmp", "docs/guide.md",
+		"cache/a.md", "src/cache/a.md", "secret1.md", "secret12.md", "Temp1.md", "temp.md",
+		"logs/a.md", "logs/README.md", "a/z.md", "a/b/c/z.md", "#hash.md", "!bang.md",
+		"vendor/lib.md", "vendor/keep.md", "plan.draft.md",
+		"nested/important.draft.md", "nested/local.md", "nested/anchored.md", "nested/x/anchored.md",
+		"nested/sub/a.md", "nested/x/sub/a.md",
+		"nested/deeper/local.md", "nested/deeper/other.md", "nested/deeper/keep-me.md",
+		"excluded-by-info.md", "global-ignored.md", "x.bak.md", "restored.bak.md",
+	}
+
+	// Check every file and every directory containing one
+	isDir := make(map[string]bool)
+	var paths []string
+	for _, file := range files {
+		createTestFile(t, repo, file, "# "+file)
+		for dir := filepath.ToSlash(filepath.Dir(file)); dir != "." && !isDir[dir]; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			isDir[dir] = true
+			paths = append(paths, dir)
+		}
+		paths = append(paths, file)
+	}
+
+	ignoredByGit := make(map[string]bool)
+	for _, line := range strings.Split(git(strings.Join(paths, "\n")+"\n", "check-ignore", "--stdin"), "\n") {
+		if line != "" {
+			ignoredByGit[line] = true
+		}
+	}
+
+	// Paths inside ignored directories are reported by check-ignore only
+	// when a pattern matches them directly, so resolve parents as git's
+	// directory walk would
+	ignoredByWalk := func(p string) bool {
+		for dir := filepath.ToSlash(filepath.Dir(p)); dir != "."; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			if ignoredByGit[dir] {
+				return true
+			}
+		}
+		return ignoredByGit[p]
+	}
+
+	mgr := NewGitignoreManager(repo)
+	for _, p := range paths {
+		expected := ignoredByWalk(p)
+		if result := mgr.IsIgnored(p, isDir[p]); result != expected {
+			t.Errorf("IsIgnored(%q): git says %v, got %v", p, expected, result)
+		}
+	}
+
+	// A subdirectory root sees the same rules
+	sub := NewGitignoreManager(filepath.Join(repo, "nested"))
+	for _, p := range paths {
+		rel, ok := strings.CutPrefix(p, "nested/")
+		if !ok {
+			continue
+		}
+		if result, expected := sub.IsIgnored(rel, isDir[p]), ignoredByWalk(p); result != expected {
+			t.Errorf("IsIgnored(%q) from nested: git says %v, got %v", rel, expected, result)
+		}
+	}
+}
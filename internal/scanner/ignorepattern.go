@@ -0,0 +1,253 @@
+package scanner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignoreFile holds the patterns read from one ignore file.
+type ignoreFile struct {
+	base     string // Directory the patterns are relative to, slash-separated from the top of the working copy ("" = top)
+	patterns []ignorePattern
+}
+
+// readIgnoreFile reads the patterns of an ignore file. A missing file is
+// not an error and returns nil.
+func readIgnoreFile(filename, base string) (*ignoreFile, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	ignore := &ignoreFile{base: base}
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		if pattern, ok := parseIgnorePattern(lines.Text()); ok {
+			ignore.patterns = append(ignore.patterns, pattern)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	return ignore, nil
+}
+
+// apply returns whether a path is ignored after the patterns of the file,
+// given whether it was ignored before them. The path is slash-separated
+// and relative to the same directory as base. A nil file changes nothing.
+func (f *ignoreFile) apply(fullPath string, isDir, ignored bool) bool {
+	if f == nil {
+		return ignored
+	}
+	rel := fullPath
+	if f.base != "" {
+		var ok bool
+		if rel, ok = strings.CutPrefix(fullPath, f.base+"/"); !ok {
+			return ignored
+		}
+	}
+	for _, pattern := range f.patterns {
+		if pattern.match(rel, isDir) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+// ignorePattern is a single gitignore rule.
+type ignorePattern struct {
+	regexp   *regexp.Regexp
+	negate   bool // Pattern started with "!" and re-includes matches
+	dirOnly  bool // Pattern ended with "/" and only matches directories
+	basename bool // Pattern has no "/" and matches names at any depth
+}
+
+// match reports whether the pattern matches a path relative to the
+// directory of its ignore file.
+func (p ignorePattern) match(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.basename {
+		relPath = path.Base(relPath)
+	}
+	return p.regexp.MatchString(relPath)
+}
+
+// parseIgnorePattern parses one line of a gitignore file. Blank lines and
+// comments return false.
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	line = trimTrailingSpaces(strings.TrimSuffix(line, "\r"))
+	if line == "" || line[0] == '#' {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+	if line[0] == '!' {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return ignorePattern{}, false
+	}
+
+	// A slash anywhere but the end anchors the pattern to its directory
+	p.basename = !strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	re, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return ignorePattern{}, false
+	}
+	p.regexp = re
+	return p, true
+}
+
+// trimTrailingSpaces removes trailing spaces unless escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		if end > 1 && line[end-2] == '\\' {
+			break
+		}
+		end--
+	}
+	return line[:end]
+}
+
+// globToRegexp translates a gitignore glob to a regular expression. "*"
+// and "?" do not match "/", "[...]" is a character class, a backslash
+// escapes the next character, and "**" between slashes matches any number
+// of directories.
+func globToRegexp(glob string) string {
+	runes := []rune(glob)
+	var sb strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '*':
+			start := i
+			for i+1 < len(runes) && runes[i+1] == '*' {
+				i++
+			}
+			wholeSegment := (start == 0 || runes[start-1] == '/') && i > start
+			switch {
+			case wholeSegment && i+1 < len(runes) && runes[i+1] == '/':
+				// "**/" matches zero or more leading directories
+				sb.WriteString("(?:.*/)?")
+				i++
+			case wholeSegment && i+1 == len(runes):
+				// A trailing "**" matches everything inside
+				sb.WriteString(".*")
+			default:
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := classEnd(runes, i)
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			sb.WriteString(classToRegexp(runes[i+1 : end]))
+			i = end
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
+
+// classEnd returns the index of the "]" closing the class opened at
+// start, or -1 if it is not closed.
+func classEnd(runes []rune, start int) int {
+	i := start + 1
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		i++
+	}
+	// A "]" right after the opening bracket is literal
+	if i < len(runes) && runes[i] == ']' {
+		i++
+	}
+	for ; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '[':
+			if end := namedClassEnd(runes, i); end >= 0 {
+				i = end
+			}
+		case ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// namedClassEnd returns the index of the "]" ending a named class such as
+// [:alpha:] that starts at i, or -1 if there is none.
+func namedClassEnd(runes []rune, i int) int {
+	if i+1 >= len(runes) || runes[i] != '[' || runes[i+1] != ':' {
+		return -1
+	}
+	for j := i + 2; j+1 < len(runes); j++ {
+		if runes[j] == ':' && runes[j+1] == ']' {
+			return j + 1
+		}
+	}
+	return -1
+}
+
+// classToRegexp translates the contents of a glob character class.
+// Classes never match "/".
+func classToRegexp(class []rune) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+
+	first := 0
+	if len(class) > 0 && (class[0] == '!' || class[0] == '^') {
+		sb.WriteString("^/")
+		first = 1
+	}
+	for i := first; i < len(class); i++ {
+		c := class[i]
+		switch {
+		case c == '\\' && i+1 < len(class):
+			i++
+			fmt.Fprintf(&sb, `\x{%x}`, class[i])
+		case c == '[' && namedClassEnd(class, i) >= 0:
+			end := namedClassEnd(class, i)
+			sb.WriteString(string(class[i : end+1]))
+			i = end
+		case c == '-' && i > first && i < len(class)-1:
+			// A range between the surrounding characters
+			sb.WriteRune('-')
+		default:
+			fmt.Fprintf(&sb, `\x{%x}`, c)
+		}
+	}
+
+	sb.WriteString("]")
+	return sb.String()
+}
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,18 +9,49 @@ import (
 	"github.com/danjdewhurst/go-toc/internal/toc"
 )
 
+// orderFileName is the per-directory file listing entries in explicit order.
+const orderFileName = ".order"
+
+// HiddenPresets are named lists of hidden directories for AllowHidden.
+var HiddenPresets = map[string][]string{
+	"github": {".github"},
+}
+
+// ExpandHiddenPresets returns the hidden directories of the named presets.
+func ExpandHiddenPresets(names []string) ([]string, error) {
+	var allowed []string
+	for _, name := range names {
+		dirs, ok := HiddenPresets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown hidden preset %q (expected github)", name)
+		}
+		allowed = append(allowed, dirs...)
+	}
+	return allowed, nil
+}
+
 // Config holds the scanner configuration options.
 type Config struct {
 	RootPath       string   // Root directory to scan
 	IgnorePatterns []string // Glob patterns to ignore
+	Include        []string // Gitignore-style patterns whitelisting files (empty = all)
 	UseGitignore   bool     // Whether to use .gitignore patterns
 	MaxDepth       int      // Maximum recursion depth (0 = unlimited)
+	Hidden         bool     // Include hidden files and directories, except .git
+	AllowHidden    []string // Hidden names or relative paths to include without Hidden
+	FollowSymlinks bool     // Descend into symlinked directories inside the root
+	FollowExternal bool     // Follow symlinks pointing outside the root too (implies FollowSymlinks)
+	ChangedSince   string   // Only include files changed since this git revision
+	GitTracked     bool     // Only include files in the git index, instead of using .gitignore
+	Untracked      bool     // With GitTracked, also include untracked files that are not ignored
 }
 
 // Scanner handles recursive directory scanning for markdown files.
 type Scanner struct {
 	config        Config
 	gitignoreMgr  *GitignoreManager
+	tocignore     *tocignoreRules
+	include       *includeRules
 	ignoredByGlob map[string]bool // Cache for glob pattern matches
 }
 
@@ -27,10 +59,13 @@ type Scanner struct {
 func New(config Config) *Scanner {
 	s := &Scanner{
 		config:        config,
+		tocignore:     newTocignoreRules(config.RootPath),
+		include:       newIncludeRules(config.Include),
 		ignoredByGlob: make(map[string]bool),
 	}
 
-	if config.UseGitignore {
+	// Git's own file list already accounts for every ignore source
+	if config.UseGitignore && !config.GitTracked {
 		s.gitignoreMgr = NewGitignoreManager(config.RootPath)
 	}
 
@@ -42,7 +77,7 @@ type ScanResult struct {
 	Tree            *toc.Tree
 	Files           []string         // Relative paths to markdown files
 	RootPath        string           // Absolute path to root directory
-	GitignoreErrors []GitignoreError // Errors encountered while parsing .gitignore files
+	GitignoreErrors []GitignoreError // Errors encountered while parsing .gitignore and .tocignore files
 }
 
 // Scan performs the directory scan and returns a tree of markdown files.
@@ -75,6 +110,19 @@ func (s *Scanner) GetMarkdownFiles() ([]string, error) {
 func (s *Scanner) ScanWithFiles() (*ScanResult, error) {
 	tree := toc.NewTree(filepath.Base(s.config.RootPath))
 	var files []string
+	orders := make(map[string][]string) // Directory path -> entries from its .order file
+
+	if order := readOrderFile(s.config.RootPath); order != nil {
+		orders["."] = order
+	}
+
+	var tracked *trackedSet
+	if s.config.GitTracked {
+		var err error
+		if tracked, err = loadTracked(s.config.RootPath, s.config.Untracked); err != nil {
+			return nil, err
+		}
+	}
 
 	// Resolve root path for symlink validation
 	rootReal, err := filepath.EvalSymlinks(s.config.RootPath)
@@ -82,85 +130,136 @@ func (s *Scanner) ScanWithFiles() (*ScanResult, error) {
 		rootReal = s.config.RootPath // Fall back if root can't be resolved
 	}
 
-	err = filepath.WalkDir(s.config.RootPath, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+	links := make(map[string]bool) // Followed directory links that may appear in the tree
 
-		relPath, err := filepath.Rel(s.config.RootPath, path)
-		if err != nil {
-			return err
-		}
-
-		// Skip root
-		if relPath == "." {
-			return nil
-		}
+	// walk scans dir, which is the real location of the directory at
+	// logicalDir. The two differ below a followed directory link, and
+	// every path is reported at its logical location.
+	var walk func(dir, logicalDir string) error
+	walk = func(dir, logicalDir string) error {
+		return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
 
-		// Calculate depth
-		depth := strings.Count(relPath, string(os.PathSeparator)) + 1
+			if path, err = logicalPath(dir, logicalDir, path); err != nil {
+				return err
+			}
+			relPath, err := filepath.Rel(s.config.RootPath, path)
+			if err != nil {
+				return err
+			}
 
-		// Check max depth
-		if s.config.MaxDepth > 0 && depth > s.config.MaxDepth {
-			if d.IsDir() {
-				return filepath.SkipDir
+			// Skip root
+			if relPath == "." {
+				return nil
 			}
-			return nil
-		}
 
-		// Check for symlinks pointing outside root directory
-		if d.Type()&os.ModeSymlink != 0 {
-			realPath, err := filepath.EvalSymlinks(path)
-			if err != nil {
-				// Skip symlinks that can't be resolved
+			// Calculate depth
+			depth := strings.Count(relPath, string(os.PathSeparator)) + 1
+
+			// Check max depth
+			if s.config.MaxDepth > 0 && depth > s.config.MaxDepth {
 				if d.IsDir() {
 					return filepath.SkipDir
 				}
 				return nil
 			}
-			// Skip symlinks that point outside the root directory
-			if !strings.HasPrefix(realPath, rootReal) {
+
+			// Symlinks are judged by their target, and must stay inside the
+			// root unless external links are followed
+			isDir := d.IsDir()
+			isLink := d.Type()&os.ModeSymlink != 0
+			var realPath string
+			if isLink {
+				if realPath, err = filepath.EvalSymlinks(path); err != nil {
+					// Skip symlinks that can't be resolved
+					return nil
+				}
+				info, err := os.Stat(realPath)
+				if err != nil {
+					return nil
+				}
+				isDir = info.IsDir()
+				if !s.config.FollowExternal && !IsWithin(rootReal, realPath) {
+					return nil
+				}
+			}
+
+			// Load nested .gitignore files as we traverse
+			if isDir && !isLink && s.gitignoreMgr != nil {
+				s.gitignoreMgr.LoadGitignoreForDir(path)
+			}
+
+			// Check if path should be ignored
+			if (tracked != nil && !tracked.contains(relPath, isDir)) || s.shouldIgnore(relPath, isDir) {
 				if d.IsDir() {
 					return filepath.SkipDir
 				}
 				return nil
 			}
-		}
 
-		// Load nested .gitignore files as we traverse
-		if d.IsDir() && s.gitignoreMgr != nil {
-			s.gitignoreMgr.LoadGitignoreForDir(path)
-		}
+			if isLink && isDir {
+				// Descend into linked directories unless that would loop
+				if !s.followsSymlinks() || s.isLoop(path) {
+					return nil
+				}
+				links[relPath] = true
+				return walk(realPath, path)
+			}
 
-		// Check if path should be ignored
-		if s.shouldIgnore(relPath, d.IsDir()) {
-			if d.IsDir() {
-				return filepath.SkipDir
+			if isDir {
+				if order := readOrderFile(path); order != nil {
+					orders[relPath] = order
+				}
 			}
-			return nil
-		}
 
-		// Process entry - only add markdown files
-		// Parent directories are created automatically by tree.AddFile
-		if !d.IsDir() && isMarkdownFile(path) {
-			tree.AddFile(relPath)
-			files = append(files, relPath)
-		}
+			// Process entry - only add markdown files
+			// Parent directories are created automatically by tree.AddFile
+			if !isDir && isMarkdownFile(path) {
+				node := tree.AddFile(relPath)
+				node.Symlink = isLink
+				if info, err := os.Stat(path); err == nil {
+					node.ModTime = info.ModTime()
+				}
+				files = append(files, relPath)
+			}
 
-		return nil
-	})
+			return nil
+		})
+	}
 
+	err = walk(s.config.RootPath, s.config.RootPath)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.config.ChangedSince != "" {
+		if files, err = s.applyChanges(tree, files); err != nil {
+			return nil, err
+		}
+	}
+
+	// Attach explicit orders to directories that made it into the tree
+	for dirPath, order := range orders {
+		if node := tree.Find(dirPath); node != nil && node.IsDir {
+			node.Order = order
+		}
+	}
+	for dirPath := range links {
+		if node := tree.Find(dirPath); node != nil && node.IsDir {
+			node.Symlink = true
+		}
+	}
+
 	tree.Sort()
 
-	// Collect any gitignore parsing errors
+	// Collect any gitignore and tocignore parsing errors
 	var gitignoreErrors []GitignoreError
 	if s.gitignoreMgr != nil {
 		gitignoreErrors = s.gitignoreMgr.Errors()
 	}
+	gitignoreErrors = append(gitignoreErrors, s.tocignore.errors...)
 
 	return &ScanResult{
 		Tree:            tree,
@@ -172,9 +271,13 @@ func (s *Scanner) ScanWithFiles() (*ScanResult, error) {
 
 // shouldIgnore checks if a path should be ignored based on patterns.
 func (s *Scanner) shouldIgnore(relPath string, isDir bool) bool {
-	// Always ignore hidden files and directories (starting with .)
+	// Ignore hidden files and directories (starting with .) unless allowed,
+	// and never look inside .git
 	name := filepath.Base(relPath)
-	if strings.HasPrefix(name, ".") && name != "." {
+	if name == ".git" {
+		return true
+	}
+	if strings.HasPrefix(name, ".") && name != "." && !s.allowsHidden(relPath) {
 		return true
 	}
 
@@ -212,9 +315,57 @@ func (s *Scanner) shouldIgnore(relPath string, isDir bool) bool {
 		return true
 	}
 
+	// .tocignore files apply with or without gitignore support
+	if s.tocignore.isIgnored(relPath, isDir) {
+		return true
+	}
+
+	// Include patterns only whitelist files, so every directory is walked
+	if !isDir && !s.include.includes(relPath) {
+		return true
+	}
+
+	return false
+}
+
+// allowsHidden reports whether a hidden path is included, either because
+// all hidden paths are or because its name or path is allow-listed.
+func (s *Scanner) allowsHidden(relPath string) bool {
+	if s.config.Hidden {
+		return true
+	}
+
+	name := filepath.Base(relPath)
+	normalizedPath := filepath.ToSlash(relPath)
+	for _, allowed := range s.config.AllowHidden {
+		allowed = strings.TrimSuffix(filepath.ToSlash(allowed), "/")
+		if allowed != "" && (allowed == name || allowed == normalizedPath) {
+			return true
+		}
+	}
 	return false
 }
 
+// readOrderFile reads the .order file in dirPath, which lists entry names
+// one per line in the order they should appear. Blank lines and lines
+// starting with # are skipped. Returns nil if the file does not exist.
+func readOrderFile(dirPath string) []string {
+	data, err := os.ReadFile(filepath.Join(dirPath, orderFileName))
+	if err != nil {
+		return nil
+	}
+
+	order := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		order = append(order, line)
+	}
+	return order
+}
+
 // isMarkdownFile checks if a file has a markdown extension.
 func isMarkdownFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
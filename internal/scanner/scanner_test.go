@@ -3,7 +3,11 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/toc"
 )
 
 func TestScannerBasic(t *testing.T) {
@@ -131,6 +135,49 @@ func TestScannerHiddenFiles(t *testing.T) {
 	}
 }
 
+func TestScannerAllowHidden(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "visible.md", "# Visible")
+	createTestFile(t, tmpDir, ".hidden.md", "# Hidden")
+	createTestFile(t, tmpDir, ".github/CONTRIBUTING.md", "# Contributing")
+	createTestFile(t, tmpDir, ".github/ISSUE_TEMPLATE/bug.md", "# Bug")
+	createTestFile(t, tmpDir, ".github/.draft.md", "# Hidden in allowed dir")
+	createTestFile(t, tmpDir, ".changeset/brave-fox.md", "# Changeset")
+	createTestFile(t, tmpDir, ".git/notes.md", "# Never")
+	createTestFile(t, tmpDir, "sub/.git/notes.md", "# Never")
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{"default", Config{}, "visible.md"},
+		{"allow-list", Config{AllowHidden: HiddenPresets["github"]},
+			".github/CONTRIBUTING.md,.github/ISSUE_TEMPLATE/bug.md,visible.md"},
+		{"allow-list by path", Config{AllowHidden: []string{".changeset/", ".hidden.md"}},
+			".changeset/brave-fox.md,.hidden.md,visible.md"},
+		{"hidden", Config{Hidden: true},
+			".changeset/brave-fox.md,.github/.draft.md,.github/CONTRIBUTING.md,.github/ISSUE_TEMPLATE/bug.md,.hidden.md,visible.md"},
+		{"git never", Config{Hidden: true, AllowHidden: []string{".git"}, IgnorePatterns: []string{".github", ".changeset", ".hidden.md"}},
+			"visible.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.RootPath = tmpDir
+			got := strings.Join(scanFiles(t, tt.config), ",")
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestIsMarkdownFile(t *testing.T) {
 	tests := []struct {
 		path     string
@@ -560,3 +607,57 @@ func TestScannerSymlinkValidation(t *testing.T) {
 		t.Error("external-link.md (symlink to external file) should have been excluded")
 	}
 }
+
+func TestScannerOrderFile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "intro.md", "# Intro")
+	createTestFile(t, tmpDir, "zz-last.md", "# Last")
+	createTestFile(t, tmpDir, "guide/b.md", "# B")
+	createTestFile(t, tmpDir, "guide/a.md", "# A")
+	createTestFile(t, tmpDir, ".order", "# reading order\nzz-last.md\n\nintro.md\n")
+	createTestFile(t, tmpDir, "guide/.order", "b.md\na.md\n")
+
+	s := New(Config{RootPath: tmpDir})
+	tree, err := s.Scan()
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	var names []string
+	tree.Walk(func(node *toc.Node, depth int, isLast bool) {
+		names = append(names, node.Name)
+	})
+
+	expected := "zz-last.md intro.md guide b.md a.md"
+	if strings.Join(names, " ") != expected {
+		t.Errorf("expected %q, got %q", expected, strings.Join(names, " "))
+	}
+}
+
+func TestScannerModTime(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "README.md", "# README")
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(filepath.Join(tmpDir, "README.md"), modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := New(Config{RootPath: tmpDir}).Scan()
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	if node := tree.Find("README.md"); node == nil || !node.ModTime.Equal(modTime) {
+		t.Errorf("expected ModTime %v, got %+v", modTime, node)
+	}
+}
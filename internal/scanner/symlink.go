@@ -0,0 +1,55 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// followsSymlinks reports whether symlinked directories are descended into.
+func (s *Scanner) followsSymlinks() bool {
+	return s.config.FollowSymlinks || s.config.FollowExternal
+}
+
+// isLoop reports whether the directory a link at path points to is the
+// root or one of the link's parent directories, which would make following
+// it recurse forever. Directories are compared by device and inode, so
+// loops through any number of links are caught.
+func (s *Scanner) isLoop(path string) bool {
+	target, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(dir); err == nil && os.SameFile(target, info) {
+			return true
+		}
+		if !IsWithin(s.config.RootPath, dir) || dir == s.config.RootPath {
+			return false
+		}
+	}
+}
+
+// IsWithin reports whether path is root or inside it. Both must be clean
+// paths of the same kind, e.g. both absolute.
+func IsWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || filepath.IsAbs(rel) {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// logicalPath maps a path found below dir to the same path below
+// logicalDir, where dir is mounted through a directory link.
+func logicalPath(dir, logicalDir, path string) (string, error) {
+	if dir == logicalDir {
+		return path, nil
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(logicalDir, rel), nil
+}
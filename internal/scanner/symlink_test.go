@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// symlink creates a link at name inside base, skipping the test where
+// symlinks are not supported.
+func symlink(t *testing.T, target, base, name string) {
+	t.Helper()
+	if err := os.Symlink(target, filepath.Join(base, name)); err != nil {
+		t.Skipf("Cannot create symlinks on this system: %v", err)
+	}
+}
+
+func TestScannerFollowSymlinks(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "docs")
+	createTestFile(t, root, "README.md", "# README")
+	createTestFile(t, root, "shared/intro.md", "# Intro")
+	createTestFile(t, tmpDir, "docs-other/secret.md", "# Outside, but shares a prefix")
+	createTestFile(t, tmpDir, "external/guide.md", "# Outside")
+
+	symlink(t, filepath.Join(root, "shared"), root, "linked")
+	symlink(t, filepath.Join(tmpDir, "docs-other"), root, "prefix")
+	symlink(t, filepath.Join(tmpDir, "external"), root, "external")
+	symlink(t, root, filepath.Join(root, "shared"), "loop")
+	symlink(t, filepath.Join(root, "shared"), filepath.Join(root, "shared"), "self")
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{"off", Config{}, "README.md,shared/intro.md"},
+		// Links back to the root or a parent are loops and never followed
+		{"inside root", Config{FollowSymlinks: true}, "README.md,linked/intro.md,shared/intro.md"},
+		{"external", Config{FollowExternal: true},
+			"README.md,external/guide.md,linked/intro.md,prefix/secret.md,shared/intro.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.RootPath = root
+			got := strings.Join(scanFiles(t, tt.config), ",")
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	tree, err := New(Config{RootPath: root, FollowSymlinks: true}).Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node := tree.Find("linked"); node == nil || !node.Symlink {
+		t.Errorf("expected linked directory to be marked as a symlink, got %+v", node)
+	}
+	if node := tree.Find("shared"); node == nil || node.Symlink {
+		t.Errorf("expected shared directory not to be marked as a symlink, got %+v", node)
+	}
+}
+
+func TestIsWithin(t *testing.T) {
+	sep := string(filepath.Separator)
+	root := filepath.Join(sep, "docs")
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{root, true},
+		{filepath.Join(root, "guide.md"), true},
+		{filepath.Join(root, "..docs", "x.md"), true},
+		{filepath.Join(sep, "docs-other"), false},
+		{filepath.Join(sep, "docs-other", "x.md"), false},
+		{sep, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWithin(root, tt.path); got != tt.expected {
+			t.Errorf("IsWithin(%q, %q): expected %v, got %v", root, tt.path, tt.expected, got)
+		}
+	}
+}
+
+func TestScannerSymlinkCycle(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "one/a.md", "# A")
+	createTestFile(t, tmpDir, "two/b.md", "# B")
+	symlink(t, filepath.Join(tmpDir, "two"), tmpDir, "one/two")
+	symlink(t, filepath.Join(tmpDir, "one"), tmpDir, "two/one")
+
+	got := strings.Join(scanFiles(t, Config{RootPath: tmpDir, FollowSymlinks: true}), ",")
+	expected := "one/a.md,one/two/b.md,two/b.md,two/one/a.md"
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// tocignoreFileName is the per-directory file listing paths to leave out of
+// the TOC, in gitignore syntax.
+const tocignoreFileName = ".tocignore"
+
+// tocignoreRules applies the .tocignore files below the scanned root. They
+// follow the same precedence as .gitignore files: parents first, and the
+// last matching pattern wins.
+type tocignoreRules struct {
+	rootPath string                 // Scanned root directory
+	files    map[string]*ignoreFile // Slash-separated directory relative to the root -> its .tocignore
+	checked  map[string]bool        // Directories already looked at for a .tocignore
+	errors   []GitignoreError       // Collected errors from .tocignore parsing
+}
+
+// newTocignoreRules creates the rules for a root directory. Files are
+// loaded as directories are first checked.
+func newTocignoreRules(rootPath string) *tocignoreRules {
+	return &tocignoreRules{
+		rootPath: rootPath,
+		files:    make(map[string]*ignoreFile),
+		checked:  make(map[string]bool),
+	}
+}
+
+// fileFor returns the .tocignore of a directory, loading it on first use.
+func (r *tocignoreRules) fileFor(dir string) *ignoreFile {
+	if !r.checked[dir] {
+		r.checked[dir] = true
+		filename := filepath.Join(r.rootPath, filepath.FromSlash(dir), tocignoreFileName)
+		ignore, err := readIgnoreFile(filename, dir)
+		if err != nil {
+			r.errors = append(r.errors, GitignoreError{Path: filename, Err: err})
+		}
+		r.files[dir] = ignore
+	}
+	return r.files[dir]
+}
+
+// isIgnored reports whether a path relative to the root is excluded by a
+// .tocignore. Parent directories are checked first, since nothing inside an
+// excluded directory can be re-included.
+func (r *tocignoreRules) isIgnored(relPath string, isDir bool) bool {
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	for i := range parts {
+		fullPath := strings.Join(parts[:i+1], "/")
+		ignored := false
+		for j := 0; j <= i; j++ {
+			ignored = r.fileFor(strings.Join(parts[:j], "/")).apply(fullPath, i < len(parts)-1 || isDir, ignored)
+		}
+		if ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// includeRules is a whitelist of paths in gitignore syntax, relative to the
+// scanned root. A "!" pattern takes paths back out again.
+type includeRules struct {
+	patterns []ignorePattern
+}
+
+// newIncludeRules parses include patterns. It returns nil when there are
+// none, which includes everything.
+func newIncludeRules(patterns []string) *includeRules {
+	var rules includeRules
+	for _, line := range patterns {
+		if pattern, ok := parseIgnorePattern(line); ok {
+			rules.patterns = append(rules.patterns, pattern)
+		}
+	}
+	if len(rules.patterns) == 0 {
+		return nil
+	}
+	return &rules
+}
+
+// includes reports whether a file is whitelisted. A pattern matching the
+// file or one of its parent directories decides, and the last one wins.
+func (r *includeRules) includes(relPath string) bool {
+	if r == nil {
+		return true
+	}
+
+	filePath := filepath.ToSlash(relPath)
+	included := false
+	for _, pattern := range r.patterns {
+		if pattern.match(filePath, false) || matchesParent(pattern, filePath) {
+			included = !pattern.negate
+		}
+	}
+	return included
+}
+
+// matchesParent reports whether a pattern matches a directory containing
+// the file.
+func matchesParent(pattern ignorePattern, filePath string) bool {
+	for dir := path.Dir(filePath); dir != "."; dir = path.Dir(dir) {
+		if pattern.match(dir, true) {
+			return true
+		}
+	}
+	return false
+}
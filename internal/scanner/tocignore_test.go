@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// scanFiles scans with the given config and returns the sorted,
+// slash-separated files found.
+func scanFiles(t *testing.T, config Config) []string {
+	t.Helper()
+
+	result, err := New(config).ScanWithFiles()
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	files := make([]string, len(result.Files))
+	for i, file := range result.Files {
+		files[i] = filepath.ToSlash(file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestScannerTocignore(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, ".tocignore", "internal/\n*.wip.md\n")
+	createTestFile(t, tmpDir, "README.md", "# README")
+	createTestFile(t, tmpDir, "plan.wip.md", "# Plan")
+	createTestFile(t, tmpDir, "internal/notes.md", "# Notes")
+	createTestFile(t, tmpDir, "docs/.tocignore", "!keep.wip.md\n/private.md\n")
+	createTestFile(t, tmpDir, "docs/guide.md", "# Guide")
+	createTestFile(t, tmpDir, "docs/keep.wip.md", "# Kept")
+	createTestFile(t, tmpDir, "docs/private.md", "# Private")
+	createTestFile(t, tmpDir, "docs/sub/private.md", "# Not anchored here")
+
+	// Honoured without UseGitignore
+	got := strings.Join(scanFiles(t, Config{RootPath: tmpDir}), ",")
+	expected := "README.md,docs/guide.md,docs/keep.wip.md,docs/sub/private.md"
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestScannerInclude(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, "README.md", "# README")
+	createTestFile(t, tmpDir, "docs/guide.md", "# Guide")
+	createTestFile(t, tmpDir, "docs/internal/secret.md", "# Secret")
+	createTestFile(t, tmpDir, "docs/internal/public.md", "# Public")
+	createTestFile(t, tmpDir, "notes/todo.md", "# Todo")
+
+	tests := []struct {
+		name     string
+		include  []string
+		expected string
+	}{
+		{"none", nil, "README.md,docs/guide.md,docs/internal/public.md,docs/internal/secret.md,notes/todo.md"},
+		{"doublestar", []string{"docs/**"}, "docs/guide.md,docs/internal/public.md,docs/internal/secret.md"},
+		{"negation", []string{"docs/**", "!docs/internal/**"}, "docs/guide.md"},
+		{"re-include", []string{"docs/**", "!docs/internal/**", "docs/internal/public.md"}, "docs/guide.md,docs/internal/public.md"},
+		{"directory", []string{"docs", "!internal/"}, "docs/guide.md"},
+		{"basename", []string{"README.md", "todo.md"}, "README.md,notes/todo.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(scanFiles(t, Config{RootPath: tmpDir, Include: tt.include}), ",")
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/danjdewhurst/go-toc/internal/gitinfo"
+)
+
+// trackedSet holds the files git lists below the root, and every
+// directory containing one, as slash-separated relative paths.
+type trackedSet struct {
+	files map[string]bool
+	dirs  map[string]bool
+}
+
+// loadTracked lists the files in the git index below root, plus untracked
+// files that are not ignored when untracked is set.
+func loadTracked(root string, untracked bool) (*trackedSet, error) {
+	files, err := gitinfo.Files(root, untracked)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &trackedSet{
+		files: make(map[string]bool, len(files)),
+		dirs:  make(map[string]bool),
+	}
+	for _, file := range files {
+		set.files[file] = true
+		for dir := path.Dir(file); dir != "." && !set.dirs[dir]; dir = path.Dir(dir) {
+			set.dirs[dir] = true
+		}
+	}
+	return set, nil
+}
+
+// contains reports whether a file is listed, or a directory holds a
+// listed file.
+func (t *trackedSet) contains(relPath string, isDir bool) bool {
+	relPath = filepath.ToSlash(relPath)
+	if isDir {
+		return t.dirs[relPath]
+	}
+	return t.files[relPath]
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+)
+
+func TestScannerGitTracked(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	createTestFile(t, tmpDir, ".gitignore", "build/\n*.tmp.md\n")
+	createTestFile(t, tmpDir, "README.md", "# README")
+	createTestFile(t, tmpDir, "docs/guide.md", "# Guide")
+	createTestFile(t, tmpDir, "docs/forced.tmp.md", "# Committed despite .gitignore")
+	createTestFile(t, tmpDir, "docs/excluded.md", "# Ignored by info/exclude")
+	createTestFile(t, tmpDir, "build/out.md", "# Build output")
+	testutil.Git(t, tmpDir, "init", "-q")
+	testutil.Git(t, tmpDir, "add", ".gitignore", "README.md", "docs/guide.md")
+	testutil.Git(t, tmpDir, "add", "-f", "docs/forced.tmp.md")
+	testutil.Git(t, tmpDir, "commit", "-q", "-m", "initial")
+	createTestFile(t, tmpDir, ".git/info/exclude", "docs/excluded.md\n")
+
+	createTestFile(t, tmpDir, "docs/untracked.md", "# Not committed yet")
+	createTestFile(t, tmpDir, "scratch/notes.md", "# Not committed yet")
+
+	scan := func(config Config) string {
+		t.Helper()
+		result, err := New(config).ScanWithFiles()
+		if err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		return strings.Join(result.Files, " ")
+	}
+
+	if files := scan(Config{RootPath: tmpDir, GitTracked: true}); files != "README.md docs/forced.tmp.md docs/guide.md" {
+		t.Errorf("expected committed files only, got %q", files)
+	}
+
+	untracked := scan(Config{RootPath: tmpDir, GitTracked: true, Untracked: true})
+	if untracked != "README.md docs/forced.tmp.md docs/guide.md docs/untracked.md scratch/notes.md" {
+		t.Errorf("expected committed and untracked files, got %q", untracked)
+	}
+
+	// Other filters still apply, and --gitignore is ignored in favour of git
+	filtered := scan(Config{RootPath: tmpDir, GitTracked: true, UseGitignore: true, IgnorePatterns: []string{"README.md"}})
+	if filtered != "docs/forced.tmp.md docs/guide.md" {
+		t.Errorf("expected ignore patterns on top of tracked files, got %q", filtered)
+	}
+
+	// A subdirectory lists paths relative to itself
+	sub, err := New(Config{RootPath: tmpDir + "/docs", GitTracked: true}).Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	sub.Walk(func(node *toc.Node, depth int, isLast bool) {
+		names = append(names, node.Path)
+	})
+	if result := strings.Join(names, " "); result != "forced.tmp.md guide.md" {
+		t.Errorf("expected paths relative to docs, got %q", result)
+	}
+}
+
+func TestScannerGitTrackedOutsideRepository(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	testutil.Git(t, tmpDir, "--version")
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(tmpDir))
+
+	if _, err := New(Config{RootPath: tmpDir, GitTracked: true}).Scan(); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("expected not a git repository error, got %v", err)
+	}
+}
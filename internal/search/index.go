@@ -0,0 +1,367 @@
+// Package search implements an offline full-text index over markdown
+// documents with BM25 ranking.
+package search
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/danjdewhurst/go-toc/internal/parser"
+)
+
+// indexVersion is bumped whenever the on-disk format changes.
+const indexVersion = 1
+
+// BM25 tuning parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field weights: a term in a heading or frontmatter says more about a
+// document than the same term in running text.
+const (
+	weightBody        = 1.0
+	weightHeading     = 3.0
+	weightFrontmatter = 2.0
+)
+
+// maxSnippetChars limits the length of result snippets.
+const maxSnippetChars = 120
+
+// Document is an indexed file along with the fingerprint used to detect changes.
+type Document struct {
+	Path    string  // Relative path from root
+	Length  float64 // Weighted number of tokens
+	Size    int64   // File size when indexed
+	ModTime int64   // Modification time (Unix nanoseconds) when indexed
+}
+
+// Posting records the occurrences of a term in one document.
+type Posting struct {
+	Doc   int     // Index into Index.Docs
+	Freq  float64 // Weighted term frequency
+	Lines []int   // 1-based line numbers containing the term
+}
+
+// Index is an inverted index over a set of markdown documents.
+type Index struct {
+	Version   int
+	Root      string
+	Docs      []Document
+	Terms     map[string][]Posting
+	AvgLength float64
+}
+
+// Result is a single ranked search hit.
+type Result struct {
+	Path    string  // Relative path from root
+	Line    int     // Best matching line (1-based)
+	Score   float64 // BM25 relevance score
+	Snippet string  // Text of the best matching line
+}
+
+// Build parses the given files (relative to root) and indexes their text,
+// headings and frontmatter.
+func Build(root string, files []string) (*Index, error) {
+	idx := &Index{
+		Version: indexVersion,
+		Root:    root,
+		Terms:   make(map[string][]Posting),
+	}
+
+	totalLength := 0.0
+	for _, relPath := range files {
+		absPath := filepath.Join(root, relPath)
+
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := parser.ParseDocument(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to index %s: %w", relPath, err)
+		}
+
+		docID := len(idx.Docs)
+		length := idx.addDocument(docID, doc)
+		totalLength += length
+
+		idx.Docs = append(idx.Docs, Document{
+			Path:    relPath,
+			Length:  length,
+			Size:    info.Size(),
+			ModTime: info.ModTime().UnixNano(),
+		})
+	}
+
+	if len(idx.Docs) > 0 {
+		idx.AvgLength = totalLength / float64(len(idx.Docs))
+	}
+
+	return idx, nil
+}
+
+// addDocument adds the terms of doc to the index and returns its weighted length.
+func (idx *Index) addDocument(docID int, doc *parser.Document) float64 {
+	headingLines := make(map[int]bool, len(doc.Headings))
+	for _, h := range doc.Headings {
+		headingLines[h.Line] = true
+	}
+
+	postings := make(map[string]*Posting)
+	length := 0.0
+
+	for i, line := range doc.Lines {
+		lineNo := i + 1
+
+		weight := weightBody
+		switch {
+		case i < doc.BodyStart:
+			weight = weightFrontmatter
+		case headingLines[lineNo]:
+			weight = weightHeading
+		}
+
+		for _, term := range Tokenize(line) {
+			p, ok := postings[term]
+			if !ok {
+				p = &Posting{Doc: docID}
+				postings[term] = p
+			}
+			p.Freq += weight
+			if len(p.Lines) == 0 || p.Lines[len(p.Lines)-1] != lineNo {
+				p.Lines = append(p.Lines, lineNo)
+			}
+			length += weight
+		}
+	}
+
+	for term, p := range postings {
+		idx.Terms[term] = append(idx.Terms[term], *p)
+	}
+
+	return length
+}
+
+// Search ranks documents against the query using BM25 and returns up to
+// limit results (0 = unlimited), best first.
+func (idx *Index) Search(query string, limit int) []Result {
+	terms := uniqueTerms(Tokenize(query))
+	if len(terms) == 0 || len(idx.Docs) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	lineHits := make(map[int]map[int]int) // doc -> line -> matched terms
+
+	n := float64(len(idx.Docs))
+	for _, term := range terms {
+		postings := idx.Terms[term]
+		if len(postings) == 0 {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		for _, p := range postings {
+			norm := 1 - bm25B + bm25B*idx.Docs[p.Doc].Length/idx.AvgLength
+			scores[p.Doc] += idf * p.Freq * (bm25K1 + 1) / (p.Freq + bm25K1*norm)
+
+			if lineHits[p.Doc] == nil {
+				lineHits[p.Doc] = make(map[int]int)
+			}
+			for _, line := range p.Lines {
+				lineHits[p.Doc][line]++
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for docID, score := range scores {
+		results = append(results, Result{
+			Path:  idx.Docs[docID].Path,
+			Line:  bestLine(lineHits[docID]),
+			Score: score,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Path < results[j].Path
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	for i := range results {
+		results[i].Snippet = idx.snippet(results[i].Path, results[i].Line)
+	}
+
+	return results
+}
+
+// bestLine picks the line matching the most query terms, preferring the earliest.
+func bestLine(hits map[int]int) int {
+	best, bestCount := 0, 0
+	for line, count := range hits {
+		if count > bestCount || (count == bestCount && line < best) {
+			best, bestCount = line, count
+		}
+	}
+	return best
+}
+
+// snippet reads the given line of a document for display.
+func (idx *Index) snippet(relPath string, line int) string {
+	doc, err := parser.ParseDocument(filepath.Join(idx.Root, relPath))
+	if err != nil || line < 1 || line > len(doc.Lines) {
+		return ""
+	}
+
+	text := strings.Join(strings.Fields(doc.Lines[line-1]), " ")
+	if runes := []rune(text); len(runes) > maxSnippetChars {
+		text = strings.TrimSpace(string(runes[:maxSnippetChars])) + "..."
+	}
+	return text
+}
+
+// Fresh reports whether the index was built from exactly the given files
+// and none of them have changed since.
+func (idx *Index) Fresh(root string, files []string) bool {
+	if idx.Version != indexVersion || idx.Root != root || len(idx.Docs) != len(files) {
+		return false
+	}
+
+	indexed := make(map[string]Document, len(idx.Docs))
+	for _, doc := range idx.Docs {
+		indexed[doc.Path] = doc
+	}
+
+	for _, relPath := range files {
+		doc, ok := indexed[relPath]
+		if !ok {
+			return false
+		}
+		info, err := os.Stat(filepath.Join(root, relPath))
+		if err != nil || info.Size() != doc.Size || info.ModTime().UnixNano() != doc.ModTime {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Save writes the index to path, creating parent directories as needed.
+func (idx *Index) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated index
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".index-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(idx); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads an index previously written by Save.
+func Load(path string) (*Index, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var idx Index
+	if err := gob.NewDecoder(file).Decode(&idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
+}
+
+// Open loads the index at path if it is still fresh for the given files,
+// otherwise it rebuilds the index and saves it back to path.
+func Open(path, root string, files []string) (*Index, error) {
+	if idx, err := Load(path); err == nil && idx.Fresh(root, files) {
+		return idx, nil
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		// A corrupt or outdated index is simply rebuilt
+		_ = os.Remove(path)
+	}
+
+	idx, err := Build(root, files)
+	if err != nil {
+		return nil, err
+	}
+	if err := idx.Save(path); err != nil {
+		return nil, fmt.Errorf("failed to save index: %w", err)
+	}
+
+	return idx, nil
+}
+
+// CachePath returns the default index location for a root directory,
+// inside the user's cache directory so scanned trees are never modified.
+func CachePath(root string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(cacheDir, "go-toc", hex.EncodeToString(sum[:8]), "search.idx"), nil
+}
+
+// Tokenize splits text into lowercase terms of letters and digits.
+// Single-character terms are dropped as they carry no meaning on their own.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := fields[:0]
+	for _, field := range fields {
+		if len([]rune(field)) > 1 {
+			terms = append(terms, field)
+		}
+	}
+	return terms
+}
+
+// uniqueTerms removes duplicate terms while keeping their order.
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,185 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Hello, World!", []string{"hello", "world"}},
+		{"go-toc v2 a b", []string{"go", "toc", "v2"}},
+		{"Übersicht über APIs", []string{"übersicht", "über", "apis"}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result := Tokenize(tt.text)
+			if len(result) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Tokenize(%q) = %v, expected %v", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSearchRanking(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	idx, err := Build(tmpDir, []string{"deploy.md", "guide.md", "notes.md"})
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	results := idx.Search("deploy rollback", 0)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d: %+v", len(results), results)
+	}
+
+	// The document with the term in its heading outranks a passing mention
+	if results[0].Path != "deploy.md" {
+		t.Errorf("expected deploy.md first, got %s", results[0].Path)
+	}
+	if results[0].Line != 1 {
+		t.Errorf("expected best line 1, got %d", results[0].Line)
+	}
+	if results[0].Snippet != "# Deploy and Rollback" {
+		t.Errorf("unexpected snippet: %q", results[0].Snippet)
+	}
+	if results[1].Path != "guide.md" || results[1].Line != 7 {
+		t.Errorf("expected guide.md:7 second, got %s:%d", results[1].Path, results[1].Line)
+	}
+	if results[0].Score <= results[1].Score {
+		t.Error("results should be sorted by descending score")
+	}
+}
+
+func TestSearchFrontmatter(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	idx, err := Build(tmpDir, []string{"deploy.md", "guide.md", "notes.md"})
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	results := idx.Search("onboarding", 1)
+	if len(results) != 1 || results[0].Path != "guide.md" || results[0].Line != 2 {
+		t.Errorf("expected frontmatter match in guide.md:2, got %+v", results)
+	}
+}
+
+func TestSearchNoMatches(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	idx, err := Build(tmpDir, []string{"guide.md"})
+	if err != nil {
+		t.Fatalf("build failed: %v", err)
+	}
+
+	if results := idx.Search("nonexistent", 10); len(results) != 0 {
+		t.Errorf("expected no results, got %+v", results)
+	}
+	if results := idx.Search("!!", 10); len(results) != 0 {
+		t.Errorf("expected no results for empty query, got %+v", results)
+	}
+}
+
+func TestOpenRebuildsStaleIndex(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	indexPath := filepath.Join(tmpDir, "cache", "search.idx")
+	files := []string{"guide.md", "notes.md"}
+
+	idx, err := Open(indexPath, tmpDir, files)
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Fatalf("index should be saved: %v", err)
+	}
+
+	loaded, err := Load(indexPath)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !loaded.Fresh(tmpDir, files) {
+		t.Error("freshly saved index should be fresh")
+	}
+	if len(loaded.Search("rollback", 0)) != len(idx.Search("rollback", 0)) {
+		t.Error("loaded index should return the same results")
+	}
+
+	// Modify a file and make sure the change is picked up
+	notes := filepath.Join(tmpDir, "notes.md")
+	if err := os.WriteFile(notes, []byte("# Notes\n\nKubernetes upgrade checklist."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(notes, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Fresh(tmpDir, files) {
+		t.Error("index should be stale after a file changes")
+	}
+
+	idx, err = Open(indexPath, tmpDir, files)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	if results := idx.Search("kubernetes", 0); len(results) != 1 {
+		t.Errorf("expected rebuilt index to find new content, got %+v", results)
+	}
+
+	// A different file set is also stale
+	if idx.Fresh(tmpDir, []string{"guide.md"}) {
+		t.Error("index should be stale when the file set changes")
+	}
+}
+
+func TestCachePath(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/cache")
+	t.Setenv("HOME", "/tmp/home")
+
+	a, err := CachePath("/docs/a")
+	if err != nil {
+		t.Skipf("no user cache directory: %v", err)
+	}
+	b, _ := CachePath("/docs/b")
+
+	if a == b {
+		t.Error("different roots should use different index files")
+	}
+	if filepath.Base(a) != "search.idx" {
+		t.Errorf("unexpected index file name: %s", a)
+	}
+}
+
+// Helper functions
+
+func setupTestDir(t *testing.T) string {
+	t.Helper()
+
+	return testutil.TempDir(t, map[string]string{
+		"deploy.md": "# Deploy and Rollback\n\nHow releases reach production.\n\n" +
+			"To rollback a deploy, run the rollback script.\n",
+		"guide.md": "---\ndescription: onboarding for new engineers\n---\n\n# Guide\n\n" +
+			"Getting started. Ask in chat before you deploy anything.\n",
+		"notes.md": "# Notes\n\nMeeting notes.\n",
+	})
+}
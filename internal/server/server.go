@@ -0,0 +1,383 @@
+// Package server implements a local preview server that renders the table
+// of contents and the markdown documents it links to as HTML, reloading
+// pages in the browser when files change.
+package server
+
+import (
+	_ "embed"
+	"fmt"
+	"hash/fnv"
+	"html/template"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/markdown"
+	"github.com/danjdewhurst/go-toc/internal/parser"
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/toc"
+)
+
+// reloadPath is the server-sent events endpoint used for live reload.
+const reloadPath = "/_reload"
+
+// defaultPollInterval is how often files are checked for changes.
+const defaultPollInterval = 500 * time.Millisecond
+
+//go:embed templates/page.html.tmpl
+var pageTemplateText string
+
+// pageTemplate renders a single document.
+var pageTemplate = template.Must(template.New("page").Parse(pageTemplateText))
+
+// reloadScript reconnects to the reload endpoint and refreshes the page on
+// change. It is injected into every HTML page the server returns.
+const reloadScript = `<script>
+(function () {
+  var events = new EventSource("` + reloadPath + `");
+  events.addEventListener("reload", function () { location.reload(); });
+})();
+</script>
+`
+
+// Config holds options for the preview server.
+type Config struct {
+	Scanner      scanner.Config // Scanner options used to discover documents
+	Title        string         // Title of the home page
+	Addr         string         // Address the server listens on; requests for other hosts are rejected
+	SummaryChars int            // Maximum characters for summaries on the home page
+	PollInterval time.Duration  // How often to check for changes (default: 500ms)
+}
+
+// Server serves the rendered docs over HTTP.
+type Server struct {
+	config Config
+
+	printMu   sync.Mutex // Guards checked and lastPrint
+	checked   time.Time  // When the fingerprint was last computed
+	lastPrint uint64     // Fingerprint of the scanned files at checked
+
+	summaryMu    sync.Mutex               // Guards summaryCache
+	summaryCache map[string]cachedSummary // Home page summaries by absolute path
+}
+
+// cachedSummary is a summary along with the file state it was read from.
+type cachedSummary struct {
+	size    int64
+	modTime time.Time
+	summary string
+}
+
+// page is the data passed to the document template.
+type page struct {
+	Title   string
+	Path    string
+	Home    string
+	Content template.HTML
+	Reload  template.HTML
+}
+
+// New creates a new preview server.
+func New(config Config) *Server {
+	if config.Title == "" {
+		config.Title = "Table of Contents"
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	return &Server{config: config}
+}
+
+// ServeHTTP routes requests to the home page, the reload endpoint, rendered
+// documents or static files below the root.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.allowedHost(r.Host) {
+		http.Error(w, "invalid host", http.StatusForbidden)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/":
+		s.serveHome(w)
+	case reloadPath:
+		s.serveReload(w, r)
+	default:
+		s.serveFile(w, r)
+	}
+}
+
+// allowedHost reports whether a request's Host header names this server:
+// its listen address, localhost, 127.0.0.1 or ::1. Anything else could be
+// a DNS rebinding attack reading the docs through a visitor's browser.
+func (s *Server) allowedHost(host string) bool {
+	if host == s.config.Addr {
+		return true
+	}
+
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	hostname = strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")
+	if listenHost, _, err := net.SplitHostPort(s.config.Addr); err == nil && listenHost != "" && hostname == listenHost {
+		return true
+	}
+	return hostname == "localhost" || hostname == "127.0.0.1" || hostname == "::1"
+}
+
+// scan walks the documentation root. Every request rescans so pages always
+// reflect the current state of the files.
+func (s *Server) scan() (*scanner.ScanResult, error) {
+	result, err := scanner.New(s.config.Scanner).ScanWithFiles()
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	return result, nil
+}
+
+// serveHome renders the table of contents as the home page.
+func (s *Server) serveHome(w http.ResponseWriter) {
+	result, err := s.scan()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	summaries := s.summaries(result)
+	result.Tree.PromoteIndex(toc.DefaultIndexNames)
+	gen := toc.NewGenerator(toc.GeneratorConfig{
+		Title:          s.config.Title,
+		Format:         toc.FormatHTML,
+		IncludeSummary: true,
+		Summaries:      summaries,
+		OpenDepth:      1,
+	})
+
+	writeHTML(w, injectReload(gen.Generate(result.Tree)))
+}
+
+// summaries returns the home page summary of every scanned file, keyed by
+// slash-separated path. Summaries are cached and only extracted again once
+// a file's size or modification time changes.
+func (s *Server) summaries(result *scanner.ScanResult) map[string]string {
+	s.summaryMu.Lock()
+	defer s.summaryMu.Unlock()
+
+	cache := make(map[string]cachedSummary, len(result.Files))
+	summaries := make(map[string]string)
+	for _, relPath := range result.Files {
+		absPath := filepath.Join(result.RootPath, relPath)
+		info, err := os.Stat(absPath)
+		if err != nil {
+			continue
+		}
+
+		entry, ok := s.summaryCache[absPath]
+		if !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+			entry = cachedSummary{size: info.Size(), modTime: info.ModTime()}
+			entry.summary, _ = parser.ExtractSummary(absPath, s.config.SummaryChars)
+		}
+		cache[absPath] = entry
+		if entry.summary != "" {
+			summaries[filepath.ToSlash(relPath)] = entry.summary
+		}
+	}
+	s.summaryCache = cache
+	return summaries
+}
+
+// serveFile renders a scanned markdown document, a directory's index
+// document, or serves any other file (such as an image) from the root.
+// Hidden paths are never served unless they are documents the scanner
+// included, markdown files the scanner ignored are treated as missing, and
+// other files are only served if they resolve to a path inside the root.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	urlPath := path.Clean("/" + r.URL.Path)
+
+	result, err := s.scan()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	relPath := filepath.FromSlash(strings.TrimPrefix(urlPath, "/"))
+	for _, part := range strings.Split(urlPath, "/") {
+		// Hidden documents are only served when the scanner included them
+		if strings.HasPrefix(part, ".") && !(isMarkdown(relPath) && slices.Contains(result.Files, relPath)) {
+			http.NotFound(w, r)
+			return
+		}
+	}
+	absPath := filepath.Join(result.RootPath, relPath)
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if info.IsDir() {
+		for _, name := range toc.DefaultIndexNames {
+			indexPath := filepath.Join(relPath, name)
+			if slices.Contains(result.Files, indexPath) {
+				s.serveDocument(w, result.RootPath, indexPath)
+				return
+			}
+		}
+		http.NotFound(w, r)
+		return
+	}
+
+	if isMarkdown(relPath) {
+		if !slices.Contains(result.Files, relPath) {
+			http.NotFound(w, r)
+			return
+		}
+		s.serveDocument(w, result.RootPath, relPath)
+		return
+	}
+
+	// Other files must really be inside the root, not reached through a
+	// symlink pointing elsewhere
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	rootReal, err := filepath.EvalSymlinks(result.RootPath)
+	if err != nil || !scanner.IsWithin(rootReal, realPath) {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeFile(w, r, realPath)
+}
+
+// serveDocument renders a markdown document as an HTML page.
+func (s *Server) serveDocument(w http.ResponseWriter, root, relPath string) {
+	doc, err := parser.ParseDocument(filepath.Join(root, relPath))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read %s: %v", relPath, err), http.StatusInternalServerError)
+		return
+	}
+
+	urlPath := filepath.ToSlash(relPath)
+	title := doc.Title()
+	if title == "" {
+		title = urlPath
+	}
+
+	data := page{
+		Title: title,
+		Path:  urlPath,
+		Home:  s.config.Title,
+		// Rendered from a local file the user chose to preview
+		Content: template.HTML(markdown.ToHTML(doc.Body())),
+		Reload:  template.HTML(reloadScript),
+	}
+
+	var sb strings.Builder
+	if err := pageTemplate.Execute(&sb, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeHTML(w, sb.String())
+}
+
+// serveReload streams a "reload" server-sent event as soon as any scanned
+// file is added, removed or modified, then ends the stream. The browser
+// reloads the page, which opens a new stream. All open pages share the
+// same fingerprint, so the root is scanned at most once per interval.
+func (s *Server) serveReload(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	initial := s.fingerprint()
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if s.fingerprint() != initial {
+				fmt.Fprint(w, "event: reload\ndata: changed\n\n")
+				flusher.Flush()
+				return
+			}
+		}
+	}
+}
+
+// fingerprint returns the current fingerprint of the doc set, computing it
+// again once the last one is older than the poll interval.
+func (s *Server) fingerprint() uint64 {
+	s.printMu.Lock()
+	defer s.printMu.Unlock()
+
+	if s.checked.IsZero() || time.Since(s.checked) >= s.config.PollInterval {
+		s.lastPrint = s.computeFingerprint()
+		s.checked = time.Now()
+	}
+	return s.lastPrint
+}
+
+// computeFingerprint hashes the path, size and modification time of every
+// scanned file, so any change to the doc set changes the result.
+func (s *Server) computeFingerprint() uint64 {
+	result, err := s.scan()
+	if err != nil {
+		return 0
+	}
+
+	h := fnv.New64a()
+	for _, relPath := range result.Files {
+		info, err := os.Stat(filepath.Join(result.RootPath, relPath))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", relPath, info.Size(), info.ModTime().UnixNano())
+	}
+	return h.Sum64()
+}
+
+// injectReload adds the live reload script to an HTML page.
+func injectReload(page string) string {
+	if i := strings.LastIndex(page, "</body>"); i != -1 {
+		return page[:i] + reloadScript + page[i:]
+	}
+	return page + reloadScript
+}
+
+// writeHTML writes an HTML response that browsers never cache, so edits
+// always show up on reload.
+func writeHTML(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, body)
+}
+
+// isMarkdown reports whether a path has a markdown extension.
+func isMarkdown(relPath string) bool {
+	ext := strings.ToLower(filepath.Ext(relPath))
+	return ext == ".md" || ext == ".markdown"
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danjdewhurst/go-toc/internal/scanner"
+	"github.com/danjdewhurst/go-toc/internal/testutil"
+)
+
+func TestServerHome(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	code, body := get(t, newTestServer(tmpDir), "/")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+
+	for _, want := range []string{
+		`<a href="docs/guide.md">guide.md</a>`,
+		"Getting started guide for new users.",
+		`new EventSource("/_reload")`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q on the home page", want)
+		}
+	}
+	if strings.Contains(body, "secret.md") {
+		t.Error("ignored files should not be listed")
+	}
+}
+
+func TestServerDocument(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	srv := newTestServer(tmpDir)
+
+	code, body := get(t, srv, "/docs/guide.md")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	for _, want := range []string{
+		"<title>Guide</title>",
+		`<h1 id="guide">Guide</h1>`,
+		`<a href="api/handlers.md">handlers</a>`,
+		`new EventSource("/_reload")`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in rendered document, got:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "draft: false") {
+		t.Error("frontmatter should not be rendered")
+	}
+
+	// Directories render their index document
+	if _, body := get(t, srv, "/docs/api/"); !strings.Contains(body, "<h1 id=\"handlers\">Handlers</h1>") {
+		t.Errorf("expected directory to render its README, got:\n%s", body)
+	}
+
+	// Allowed hidden documents are served
+	if code, body := get(t, srv, "/.github/CONTRIBUTING.md"); code != http.StatusOK || !strings.Contains(body, "Contributing") {
+		t.Errorf("expected allowed hidden document, got %d %q", code, body)
+	}
+
+	// Other files are served as they are
+	if code, body := get(t, srv, "/docs/diagram.svg"); code != http.StatusOK || body != "<svg></svg>" {
+		t.Errorf("expected static file, got %d %q", code, body)
+	}
+}
+
+func TestServerNotFound(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	srv := newTestServer(tmpDir)
+
+	for _, path := range []string{
+		"/missing.md",
+		"/secret.md",        // Ignored by the scanner
+		"/.env",             // Hidden
+		"/.github/logo.svg", // Hidden and not a document
+		"/.notes/todo.md",   // Hidden and not allowed
+		"/../../etc/passwd", // Outside the root
+		"/docs/",            // No index document
+	} {
+		if code, _ := get(t, srv, path); code != http.StatusNotFound {
+			t.Errorf("GET %s: expected 404, got %d", path, code)
+		}
+	}
+}
+
+func TestServerSymlinkOutsideRoot(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	outside, err := os.MkdirTemp("", "go-toc-server-outside")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+	if err := os.WriteFile(filepath.Join(outside, "hostname"), []byte("secret-host"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(outside, filepath.Join(tmpDir, "etclink")); err != nil {
+		t.Skipf("Cannot create symlinks on this system: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(tmpDir, "docs"), filepath.Join(tmpDir, "docslink")); err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestServer(tmpDir)
+
+	if code, body := get(t, srv, "/etclink/hostname"); code != http.StatusNotFound {
+		t.Errorf("expected 404 for a file outside the root, got %d %q", code, body)
+	}
+	if code, body := get(t, srv, "/docslink/diagram.svg"); code != http.StatusOK || body != "<svg></svg>" {
+		t.Errorf("expected symlinks inside the root to be served, got %d %q", code, body)
+	}
+}
+
+func TestServerHost(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	srv := New(Config{
+		Scanner: scanner.Config{RootPath: tmpDir},
+		Addr:    "192.168.1.5:3000",
+	})
+
+	tests := []struct {
+		host string
+		want int
+	}{
+		{"localhost:3000", http.StatusOK},
+		{"127.0.0.1:3000", http.StatusOK},
+		{"localhost", http.StatusOK},
+		{"[::1]", http.StatusOK},
+		{"[::1]:3000", http.StatusOK},
+		{"192.168.1.5:3000", http.StatusOK},
+		{"evil.example", http.StatusForbidden},
+		{"evil.example:3000", http.StatusForbidden},
+		{"localhost.evil.example", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Host = tt.host
+		srv.ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("Host %q: expected %d, got %d", tt.host, tt.want, rec.Code)
+		}
+	}
+}
+
+func TestServerReload(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	ts := httptest.NewServer(newTestServer(tmpDir))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/_reload", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected event stream, got %q", ct)
+	}
+
+	// Let the stream take its initial fingerprint, then change a file
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "new.md"), []byte("# New"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("expected reload event, got error: %v", err)
+	}
+	if line != "event: reload\n" {
+		t.Errorf("expected reload event, got %q", line)
+	}
+}
+
+func TestServerFingerprintShared(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	srv := newTestServer(tmpDir)
+	srv.config.PollInterval = time.Hour
+
+	initial := srv.fingerprint()
+	if err := os.WriteFile(filepath.Join(tmpDir, "docs", "new.md"), []byte("# New"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if srv.fingerprint() != initial {
+		t.Error("expected the fingerprint to be reused within the poll interval")
+	}
+
+	srv.config.PollInterval = time.Nanosecond
+	if srv.fingerprint() == initial {
+		t.Error("expected the fingerprint to change once the interval passed")
+	}
+}
+
+func TestServerSummaryCache(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+	srv := newTestServer(tmpDir)
+
+	if _, body := get(t, srv, "/"); !strings.Contains(body, "The main readme.") {
+		t.Fatal("expected the README summary on the home page")
+	}
+
+	// Same size and modification time: the cached summary is kept
+	readme := filepath.Join(tmpDir, "README.md")
+	info, err := os.Stat(readme)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(readme, []byte("# README\n\nThe next readme."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(readme, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if _, body := get(t, srv, "/"); !strings.Contains(body, "The main readme.") {
+		t.Error("expected the cached summary for an unchanged file")
+	}
+
+	// A new modification time extracts it again
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(readme, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, body := get(t, srv, "/"); !strings.Contains(body, "The next readme.") {
+		t.Error("expected the summary to be extracted again after a change")
+	}
+}
+
+func TestInjectReload(t *testing.T) {
+	result := injectReload("<html><body><p>hi</p></body></html>")
+	if !strings.HasSuffix(result, reloadScript+"</body></html>") {
+		t.Errorf("script should be injected before </body>, got %q", result)
+	}
+}
+
+// Helper functions
+
+func newTestServer(root string) *Server {
+	return New(Config{
+		Scanner: scanner.Config{
+			RootPath:       root,
+			IgnorePatterns: []string{"secret.md"},
+			AllowHidden:    scanner.HiddenPresets["github"],
+		},
+		Addr:         "localhost:8080",
+		SummaryChars: 100,
+		PollInterval: 10 * time.Millisecond,
+	})
+}
+
+func get(t *testing.T, handler http.Handler, path string) (int, string) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "localhost:8080"
+	req.URL.Path = path
+	handler.ServeHTTP(rec, req)
+
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec.Code, string(body)
+}
+
+func setupTestDir(t *testing.T) string {
+	t.Helper()
+
+	return testutil.TempDir(t, map[string]string{
+		"README.md":               "# README\n\nThe main readme.",
+		"secret.md":               "# Secret",
+		".env":                    "TOKEN=abc",
+		".github/CONTRIBUTING.md": "# Contributing",
+		".github/logo.svg":        "<svg></svg>",
+		".notes/todo.md":          "# Todo",
+		"docs/guide.md":           "---\ndraft: false\n---\n\n# Guide\n\nGetting started guide for new users.\n\nSee [handlers](api/handlers.md).",
+		"docs/diagram.svg":        "<svg></svg>",
+		"docs/api/README.md":      "# Handlers\n\nAPI handler documentation.",
+	})
+}
@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package terminal
+
+// windowWidth is not supported on this platform; COLUMNS must be set to
+// enable width-aware output.
+func windowWidth(fd uintptr) int {
+	return 0
+}
@@ -0,0 +1,45 @@
+// Package terminal detects interactive terminals and their size without
+// depending on anything outside the standard library.
+package terminal
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// IsTerminal reports whether w is a terminal (character device), such as
+// stdout when it is not redirected to a file or pipe.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Width returns the width of the terminal w writes to, in columns. The
+// COLUMNS environment variable takes precedence, as shells and users set
+// it to override the detected size. Returns 0 if the width is unknown.
+func Width(w io.Writer) int {
+	if columns, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && columns > 0 {
+		return columns
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	return windowWidth(f.Fd())
+}
+
+// ColorEnabled reports whether ANSI colors should be used, honouring the
+// NO_COLOR convention (https://no-color.org).
+func ColorEnabled() bool {
+	return os.Getenv("NO_COLOR") == ""
+}
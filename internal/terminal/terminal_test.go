@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Error("a buffer is not a terminal")
+	}
+
+	file, err := os.CreateTemp("", "go-toc-terminal-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if IsTerminal(file) {
+		t.Error("a regular file is not a terminal")
+	}
+}
+
+func TestWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+	if width := Width(&bytes.Buffer{}); width != 132 {
+		t.Errorf("expected COLUMNS to set the width, got %d", width)
+	}
+
+	t.Setenv("COLUMNS", "wide")
+	if width := Width(&bytes.Buffer{}); width != 0 {
+		t.Errorf("expected unknown width for a buffer, got %d", width)
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if !ColorEnabled() {
+		t.Error("expected colors by default")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled() {
+		t.Error("NO_COLOR should disable colors")
+	}
+}
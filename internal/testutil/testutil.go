@@ -0,0 +1,78 @@
+// Package testutil provides fixtures shared by the tests of several
+// packages: temporary document trees and a git runner.
+package testutil
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TempDir creates a temporary directory holding files, keyed by
+// slash-separated path, and returns its path. The caller removes it.
+func TempDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "go-toc-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteFiles(t, dir, files)
+	return dir
+}
+
+// WriteFiles writes files below dir, keyed by slash-separated path,
+// creating parent directories as needed.
+func WriteFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Git runs git in dir and returns its output, skipping the test when git
+// is not installed. Commits are made by "Test" at the current time, and
+// the user's and the system's git config are ignored.
+func Git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	return GitAt(t, dir, "", time.Time{}, args...)
+}
+
+// GitAt runs git like Git, committing as author at date. An empty author
+// or a zero date keeps the default.
+func GitAt(t *testing.T, dir, author string, date time.Time, args ...string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if author == "" {
+		author = "Test"
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME="+author, "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	if !date.IsZero() {
+		stamp := date.Format(time.RFC3339)
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+stamp, "GIT_COMMITTER_DATE="+stamp)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
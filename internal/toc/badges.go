@@ -0,0 +1,95 @@
+package toc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BadgeStatuses are the frontmatter statuses shown as badges.
+var BadgeStatuses = []string{"draft", "deprecated", "archived"}
+
+// ageUnits are the units accepted by ParseAge, longest suffix first.
+var ageUnits = []struct {
+	suffix string
+	length time.Duration
+}{
+	{"mo", 30 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+	{"y", 365 * 24 * time.Hour},
+}
+
+// ParseAge parses an age such as "90d", "6w", "12mo" or "2y". "0" and ""
+// return 0.
+func ParseAge(s string) (time.Duration, error) {
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	for _, unit := range ageUnits {
+		number, ok := strings.CutSuffix(s, unit.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 0 {
+			break
+		}
+		return time.Duration(n) * unit.length, nil
+	}
+	return 0, fmt.Errorf("invalid age %q (expected a number followed by d, w, mo or y)", s)
+}
+
+// formatAge formats an age in whole months, or days when under a month.
+func formatAge(age time.Duration) string {
+	days := int(age / (24 * time.Hour))
+	if days < 30 {
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dmo", days/30)
+}
+
+// badgesFor returns the badges of a document: its status in upper case if
+// it is one of BadgeStatuses, and "stale 14mo" style age when it was last
+// updated StaleAfter or longer ago. Nothing is returned unless Badges is
+// enabled.
+func (g *Generator) badgesFor(doc *Node) []string {
+	if !g.config.Badges || doc == nil {
+		return nil
+	}
+
+	var badges []string
+	for _, status := range BadgeStatuses {
+		if strings.EqualFold(doc.Status, status) {
+			badges = append(badges, strings.ToUpper(status))
+		}
+	}
+
+	if updated := doc.Updated(); g.config.StaleAfter > 0 && !updated.IsZero() {
+		now := g.config.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		if age := now.Sub(updated); age >= g.config.StaleAfter {
+			badges = append(badges, "stale "+formatAge(age))
+		}
+	}
+
+	return badges
+}
+
+// markdownBadges renders the badges of a node as inline code spans, each
+// preceded by a space.
+func (g *Generator) markdownBadges(node *Node) string {
+	var sb strings.Builder
+	for _, badge := range g.badgesFor(node.document()) {
+		fmt.Fprintf(&sb, " `%s`", badge)
+	}
+	return sb.String()
+}
+
+// IsDraft reports whether a node is a draft document.
+func IsDraft(node *Node) bool {
+	return !node.IsDir && strings.EqualFold(node.Status, "draft")
+}
@@ -0,0 +1,113 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"90d", 90 * day},
+		{"6w", 42 * day},
+		{"12mo", 360 * day},
+		{"2y", 730 * day},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.input)
+		if err != nil {
+			t.Errorf("ParseAge(%q) failed: %v", tt.input, err)
+		} else if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"12", "mo", "-1d", "1h", "a year"} {
+		if _, err := ParseAge(input); err == nil {
+			t.Errorf("ParseAge(%q) should fail", input)
+		}
+	}
+}
+
+func newBadgeTree(now time.Time) *Tree {
+	tree := NewTree("project")
+	tree.AddFile("README.md").ModTime = now.AddDate(0, 0, -10)
+	tree.AddFile("docs/runbook.md").ModTime = now.AddDate(0, 0, -425)
+	wip := tree.AddFile("docs/wip.md")
+	wip.ModTime = now
+	wip.Status = "draft"
+	old := tree.AddFile("docs/old.md")
+	old.ModTime = now.AddDate(-2, 0, 0)
+	old.Status = "Deprecated"
+	tree.AddFile("docs/published.md").Status = "published"
+	tree.Sort()
+	return tree
+}
+
+func TestGeneratorBadges(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tree := newBadgeTree(now)
+
+	gen := NewGenerator(GeneratorConfig{Badges: true, StaleAfter: 365 * 24 * time.Hour, Now: now})
+	result := gen.Generate(tree)
+
+	for _, want := range []string{
+		"[wip.md](docs/wip.md) `DRAFT`  \n",
+		"[old.md](docs/old.md) `DEPRECATED` `stale 24mo`  \n",
+		"[runbook.md](docs/runbook.md) `stale 14mo`  \n",
+		"[README.md](README.md)  \n",
+		"[published.md](docs/published.md)  \n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, result)
+		}
+	}
+
+	// Badges are off unless enabled
+	plain := NewGenerator(GeneratorConfig{StaleAfter: 365 * 24 * time.Hour, Now: now}).Generate(tree)
+	if strings.Contains(plain, "`DRAFT`") || strings.Contains(plain, "stale") {
+		t.Errorf("badges should be disabled by default, got:\n%s", plain)
+	}
+
+	// Without StaleAfter only statuses are badged
+	statusOnly := NewGenerator(GeneratorConfig{Badges: true, Now: now}).Generate(tree)
+	if strings.Contains(statusOnly, "stale") || !strings.Contains(statusOnly, "`DRAFT`") {
+		t.Errorf("expected status badges only, got:\n%s", statusOnly)
+	}
+}
+
+func TestGeneratorBadgesFormats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tree := newBadgeTree(now)
+	tree.PromoteIndex(DefaultIndexNames)
+	tree.Find("docs").Index = tree.Find("docs/old.md")
+
+	tests := []struct {
+		config GeneratorConfig
+		want   []string
+	}{
+		{GeneratorConfig{Fancy: true}, []string{"**[docs/](docs/old.md)** `DEPRECATED`", "[wip.md](docs/wip.md) `DRAFT`\n"}},
+		{GeneratorConfig{Format: FormatDetails}, []string{"docs/</a> <code>DEPRECATED</code>", "[wip.md](docs/wip.md) `DRAFT`\n"}},
+		{GeneratorConfig{Format: FormatTerm, NoColor: true}, []string{"wip.md [DRAFT]\n"}},
+		{GeneratorConfig{Format: FormatJSON}, []string{`"status": "draft"`, `"badges": [`, `"DRAFT"`}},
+		{GeneratorConfig{Format: FormatHTML}, []string{`wip.md</a> <span class="badge">DRAFT</span>`}},
+	}
+
+	for _, tt := range tests {
+		tt.config.Badges = true
+		tt.config.Now = now
+		result := NewGenerator(tt.config).Generate(tree)
+		for _, want := range tt.want {
+			if !strings.Contains(result, want) {
+				t.Errorf("format %q: expected %q in output, got:\n%s", tt.config.Format, want, result)
+			}
+		}
+	}
+}
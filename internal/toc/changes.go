@@ -0,0 +1,35 @@
+package toc
+
+import "fmt"
+
+// Changes recorded in Node.Change.
+const (
+	ChangeAdded    = "added"
+	ChangeModified = "modified"
+	ChangeDeleted  = "deleted"
+)
+
+// deleted reports whether a node stands for a file that no longer exists.
+func (n *Node) deleted() bool {
+	return n.Change == ChangeDeleted
+}
+
+// changeMarker renders a file's change as an italic marker such as
+// " *(added)*", or "" if it has none.
+func changeMarker(node *Node) string {
+	if node.Change == "" {
+		return ""
+	}
+	return fmt.Sprintf(" *(%s)*", node.Change)
+}
+
+// fileEntry renders a file as a markdown link followed by its symlink and
+// change markers and badges. Deleted files have nothing to link to and are
+// struck through instead.
+func (g *Generator) fileEntry(node *Node) string {
+	entry := fmt.Sprintf("[%s](%s)", node.Name, node.Path)
+	if node.deleted() {
+		entry = fmt.Sprintf("~~%s~~", node.Name)
+	}
+	return entry + linkMarker(node) + changeMarker(node) + g.markdownBadges(node)
+}
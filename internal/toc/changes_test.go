@@ -0,0 +1,59 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func newChangesTree() *Tree {
+	tree := NewTree("project")
+	tree.AddFile("docs/guide.md").Change = ChangeModified
+	tree.AddFile("docs/new.md").Change = ChangeAdded
+	tree.AddFile("docs/old.md").Change = ChangeDeleted
+	tree.Sort()
+	return tree
+}
+
+func TestGeneratorChanges(t *testing.T) {
+	tree := newChangesTree()
+
+	tests := []struct {
+		config GeneratorConfig
+		want   []string
+	}{
+		{GeneratorConfig{}, []string{"[guide.md](docs/guide.md) *(modified)*  \n", "[new.md](docs/new.md) *(added)*  \n", "~~old.md~~ *(deleted)*  \n"}},
+		{GeneratorConfig{Fancy: true}, []string{"📄 ~~old.md~~ *(deleted)*\n"}},
+		{GeneratorConfig{Format: FormatDetails}, []string{"- ~~old.md~~ *(deleted)*\n"}},
+		{GeneratorConfig{Format: FormatTerm, NoColor: true, RootPath: "/project"}, []string{"old.md (deleted)\n", "new.md\x1b]8;;\x1b\\ (added)\n"}},
+		{GeneratorConfig{Format: FormatJSON}, []string{`"change": "deleted"`}},
+		{GeneratorConfig{Format: FormatHTML}, []string{`<del>old.md</del> <span class="change deleted">deleted</span>`}},
+		{GeneratorConfig{Format: FormatRST}, []string{"- old.md (deleted)\n"}},
+		{GeneratorConfig{Format: FormatAsciiDoc}, []string{"** old.md (deleted)\n"}},
+	}
+
+	for _, tt := range tests {
+		result := NewGenerator(tt.config).Generate(tree)
+		for _, want := range tt.want {
+			if !strings.Contains(result, want) {
+				t.Errorf("format %q: expected %q in output, got:\n%s", tt.config.Format, want, result)
+			}
+		}
+	}
+}
+
+func TestGeneratorChangesNavigation(t *testing.T) {
+	tree := newChangesTree()
+
+	// Deleted files have no page to link to
+	for _, format := range []Format{FormatMkDocs, FormatDocusaurus, FormatMdBook} {
+		result := NewGenerator(GeneratorConfig{Format: format}).Generate(tree)
+		if strings.Contains(result, "old") {
+			t.Errorf("format %q should leave out deleted files, got:\n%s", format, result)
+		}
+	}
+
+	rst := NewGenerator(GeneratorConfig{Format: FormatRST}).Generate(tree)
+	if strings.Contains(rst, "   docs/old\n") {
+		t.Errorf("deleted files should not be in the toctree, got:\n%s", rst)
+	}
+}
@@ -0,0 +1,40 @@
+package toc
+
+// Compact merges chains of directories that each contain only a single
+// subdirectory into one node named after the whole chain (e.g. "a/b/c"),
+// so deeply nested paths take one line instead of one per level. The
+// merged node keeps the path, children and index of the deepest directory.
+// Directories with a promoted index file and virtual directories are never
+// merged into their child, since they carry content of their own.
+func (t *Tree) Compact() {
+	for _, child := range t.Root.Children {
+		child.compact()
+	}
+}
+
+// compact merges single-child directory chains starting at this node and
+// compacts its descendants.
+func (n *Node) compact() {
+	if !n.IsDir {
+		return
+	}
+
+	for n.Index == nil && !n.Virtual && len(n.Children) == 1 {
+		child := n.Children[0]
+		if !child.IsDir || child.Virtual {
+			break
+		}
+
+		n.Name = n.Name + "/" + child.Name
+		n.Path = child.Path
+		n.Order = child.Order
+		n.Index = child.Index
+		n.Symlink = n.Symlink || child.Symlink
+		n.Children = child.Children
+		n.childIndex = child.childIndex
+	}
+
+	for _, child := range n.Children {
+		child.compact()
+	}
+}
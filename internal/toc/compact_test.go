@@ -0,0 +1,72 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("src/main/java/com/example/Guide.md")
+	tree.AddFile("docs/api/v1/reference.md")
+	tree.AddFile("docs/intro.md")
+	tree.Sort()
+
+	tree.Compact()
+
+	if result := childNames(tree.Root); result != "docs src/main/java/com/example README.md" {
+		t.Fatalf("unexpected root children %q", result)
+	}
+
+	chain := tree.Root.Children[1]
+	if chain.Path != "src/main/java/com/example" {
+		t.Errorf("merged node should keep the deepest path, got %q", chain.Path)
+	}
+	if result := childNames(chain); result != "Guide.md" {
+		t.Errorf("merged node should adopt the deepest children, got %q", result)
+	}
+
+	// docs has two children and stays, but its own chain is merged
+	if result := childNames(tree.Root.Children[0]); result != "api/v1 intro.md" {
+		t.Errorf("nested chains should be merged, got %q", result)
+	}
+}
+
+func TestCompactKeepsIndexedDirectories(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("guides/README.md")
+	tree.AddFile("guides/setup/install/linux.md")
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+
+	tree.Compact()
+
+	guides := tree.Root.Children[0]
+	if guides.Name != "guides" || guides.Index == nil {
+		t.Fatalf("directory with an index should not be merged, got %q", guides.Name)
+	}
+	if result := childNames(guides); result != "setup/install" {
+		t.Errorf("expected chain below the index to be merged, got %q", result)
+	}
+}
+
+func TestGeneratorCompact(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("a/b/c/file.md")
+	tree.Sort()
+	tree.Compact()
+
+	output := NewGenerator(GeneratorConfig{}).Generate(tree)
+	if !strings.Contains(output, "a/b/c/  \n") {
+		t.Errorf("expected merged directory line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "[file.md](a/b/c/file.md)") {
+		t.Errorf("file links should keep the full path, got:\n%s", output)
+	}
+
+	stats := GetStats(tree)
+	if stats.TotalDirectories != 1 || stats.MaxDepth != 2 {
+		t.Errorf("unexpected stats for compacted tree: %+v", stats)
+	}
+}
@@ -0,0 +1,94 @@
+package toc
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// generateDetails creates markdown where every directory is a collapsible
+// <details> block holding a nested list of its entries. GitHub and most
+// markdown renderers support these natively. Directories shallower than
+// OpenDepth start expanded.
+func (g *Generator) generateDetails(tree *Tree) string {
+	var sb strings.Builder
+
+	sb.WriteString("# ")
+	sb.WriteString(g.config.Title)
+	sb.WriteString("\n\n")
+
+	g.writeDetailsList(&sb, tree.Root.Children, 0, "")
+	sb.WriteString(g.recentSection(tree))
+
+	return sb.String()
+}
+
+// writeDetailsList writes nodes as list items at the given indentation.
+// An HTML block runs until the next blank line, so every tag line is
+// followed by one to let the markdown inside and after it render.
+func (g *Generator) writeDetailsList(sb *strings.Builder, nodes []*Node, depth int, indent string) {
+	for _, node := range nodes {
+		sb.WriteString(indent)
+		sb.WriteString("- ")
+
+		if !node.IsDir {
+			if node.Overflow > 0 {
+				sb.WriteString(overflowEntry(node))
+			} else {
+				if g.config.Fancy {
+					sb.WriteString(g.icon(node))
+				}
+				sb.WriteString(g.fileEntry(node))
+			}
+			sb.WriteString("\n")
+			g.writeDetailsNotes(sb, node.document(), indent+"  ")
+			continue
+		}
+
+		inner := indent + "  "
+		if depth < g.config.OpenDepth {
+			sb.WriteString("<details open>\n")
+		} else {
+			sb.WriteString("<details>\n")
+		}
+
+		label := html.EscapeString(node.Name + "/")
+		if g.config.Fancy {
+			label = g.icon(node) + label
+		}
+		if link := node.link(); link != "" {
+			label = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(link), label)
+		}
+		label += linkMarker(node)
+		for _, badge := range g.badgesFor(node.document()) {
+			label += " <code>" + html.EscapeString(badge) + "</code>"
+		}
+		fmt.Fprintf(sb, "%s<summary>%s</summary>\n\n", inner, label)
+
+		if doc := node.document(); doc != nil {
+			g.writeDetailsNotes(sb, doc, inner)
+			sb.WriteString("\n")
+		}
+
+		g.writeDetailsList(sb, node.Children, depth+1, inner)
+
+		fmt.Fprintf(sb, "\n%s</details>\n\n", inner)
+	}
+}
+
+// writeDetailsNotes writes the summary and tag lines of a document, if enabled.
+func (g *Generator) writeDetailsNotes(sb *strings.Builder, doc *Node, indent string) {
+	if doc == nil {
+		return
+	}
+	if g.config.IncludeSummary {
+		if summary := g.summaryFor(doc); summary != "" {
+			fmt.Fprintf(sb, "%s> %s\n", indent, summary)
+		}
+	}
+	if g.config.IncludeTags {
+		if tags := tagsFor(doc); len(tags) > 0 {
+			fmt.Fprintf(sb, "%s> Tags: %s\n", indent, strings.Join(tags, ", "))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratorDetails(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("docs/guide.md")
+	tree.AddFile("docs/api/handlers.md")
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{
+		Format:         FormatDetails,
+		OpenDepth:      1,
+		IncludeSummary: true,
+		Summaries:      map[string]string{"docs/guide.md": "Getting started."},
+	})
+	output := gen.Generate(tree)
+
+	expected := `# Table of Contents
+
+- <details open>
+  <summary>docs/</summary>
+
+  - <details>
+    <summary>api/</summary>
+
+    - [handlers.md](docs/api/handlers.md)
+
+    </details>
+
+  - [guide.md](docs/guide.md)
+    > Getting started.
+
+  </details>
+
+- [README.md](README.md)
+`
+	if output != expected {
+		t.Errorf("unexpected details output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGeneratorDetailsIndex(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("docs/README.md")
+	tree.AddFile("docs/guide.md")
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+
+	output := NewGenerator(GeneratorConfig{Format: FormatDetails, Fancy: true}).Generate(tree)
+
+	if !strings.Contains(output, `<summary><a href="docs/README.md">📁 docs/</a></summary>`) {
+		t.Errorf("expected directory summary linked to its index, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- <details>\n") {
+		t.Errorf("directories should be collapsed with an open depth of 0, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- 📄 [guide.md](docs/guide.md)") {
+		t.Errorf("expected fancy file entry, got:\n%s", output)
+	}
+}
@@ -0,0 +1,33 @@
+package toc
+
+// Exclude removes every file for which match returns true, along with any
+// directories left empty. It returns the number of files removed.
+func (t *Tree) Exclude(match func(node *Node) bool) int {
+	return t.Root.exclude(match)
+}
+
+// exclude removes matching files below this node.
+func (n *Node) exclude(match func(node *Node) bool) int {
+	removed := 0
+	kept := n.Children[:0]
+
+	for _, child := range n.Children {
+		drop := false
+		if child.IsDir {
+			removed += child.exclude(match)
+			drop = len(child.Children) == 0
+		} else if match(child) {
+			removed++
+			drop = true
+		}
+
+		if drop {
+			delete(n.childIndex, child.Name)
+		} else {
+			kept = append(kept, child)
+		}
+	}
+
+	n.Children = kept
+	return removed
+}
@@ -0,0 +1,29 @@
+package toc
+
+import "testing"
+
+func TestExclude(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("docs/guide.md")
+	tree.AddFile("docs/wip.md")
+	tree.AddFile("drafts/plans/idea.md")
+	tree.Sort()
+
+	tree.Find("docs/wip.md").Status = "draft"
+	tree.Find("drafts/plans/idea.md").Status = "Draft"
+
+	if removed := tree.Exclude(IsDraft); removed != 2 {
+		t.Errorf("expected 2 files removed, got %d", removed)
+	}
+
+	if result := childNames(tree.Root); result != "docs README.md" {
+		t.Errorf("empty directories should be removed, got %q", result)
+	}
+	if result := childNames(tree.Find("docs")); result != "guide.md" {
+		t.Errorf("expected only guide.md in docs, got %q", result)
+	}
+	if tree.Find("drafts") != nil {
+		t.Error("removed directories should not be found")
+	}
+}
@@ -3,6 +3,7 @@ package toc
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,17 +13,71 @@ const (
 	treeSpace      = "    "
 	treePipe       = "│   "
 
-	// Fancy emoji characters
+	// Default fancy icons
 	emojiFolder = "📁"
 	emojiFile   = "📄"
 )
 
+// Format selects the output format of the generator.
+type Format string
+
+const (
+	FormatMarkdown   Format = "markdown"   // ASCII tree or fancy markdown (default)
+	FormatJSON       Format = "json"       // Machine-readable JSON tree
+	FormatDetails    Format = "details"    // Collapsible <details> blocks per directory
+	FormatHTML       Format = "html"       // Standalone HTML page with sidebar and filter
+	FormatMkDocs     Format = "mkdocs"     // nav block for mkdocs.yml
+	FormatDocusaurus Format = "docusaurus" // Docusaurus sidebars.js
+	FormatMdBook     Format = "mdbook"     // mdBook SUMMARY.md
+	FormatRST        Format = "rst"        // reStructuredText toctree and bullet list
+	FormatAsciiDoc   Format = "asciidoc"   // AsciiDoc nested list with xref links
+	FormatTerm       Format = "term"       // Colored tree for reading in a terminal
+)
+
+// Formats lists all supported output formats.
+var Formats = []Format{FormatMarkdown, FormatJSON, FormatDetails, FormatHTML, FormatMkDocs, FormatDocusaurus, FormatMdBook, FormatRST, FormatAsciiDoc, FormatTerm}
+
+// ParseFormat validates a format name. An empty name selects FormatMarkdown.
+func ParseFormat(name string) (Format, error) {
+	if name == "" {
+		return FormatMarkdown, nil
+	}
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = string(format)
+	}
+	return "", fmt.Errorf("unknown format %q (expected %s)", name, strings.Join(names, ", "))
+}
+
+// UsesTitles reports whether the format labels entries with document
+// titles, which must then be loaded into the tree before generating.
+func (f Format) UsesTitles() bool {
+	return f == FormatMkDocs || f == FormatDocusaurus || f == FormatMdBook
+}
+
 // GeneratorConfig holds options for ToC generation.
 type GeneratorConfig struct {
 	Title           string            // Title for the ToC
+	Format          Format            // Output format (default: markdown)
 	IncludeSummary  bool              // Whether to include file summaries
 	Summaries       map[string]string // Map of file path to summary
+	IncludeTags     bool              // Whether to include a tag line for each file
 	Fancy           bool              // Use emoji icons instead of ASCII tree
+	Icons           *IconSet          // Icons for fancy output (default: emoji theme)
+	Badges          bool              // Show status and staleness badges after entries
+	StaleAfter      time.Duration     // Age after which a document is badged as stale (0 = never)
+	Now             time.Time         // Reference time for staleness (default: time.Now)
+	Recent          int               // Files listed in a "Recently Updated" section (markdown formats, 0 = none)
+	OpenDepth       int               // Directories shallower than this start expanded (details and html formats)
+	Width           int               // Terminal width for truncating summaries (term format, 0 = unknown)
+	NoColor         bool              // Disable ANSI colors (term format)
+	RootPath        string            // Absolute path of the scanned root, for hyperlinks (term format, "" = none)
 	GenerateAnchors bool              // Add anchor IDs to entries for linking
 }
 
@@ -39,20 +94,85 @@ func NewGenerator(config GeneratorConfig) *Generator {
 	if config.Summaries == nil {
 		config.Summaries = make(map[string]string)
 	}
+	if config.Icons == nil {
+		config.Icons, _ = IconTheme(DefaultIconTheme)
+	}
 
 	return &Generator{
 		config: config,
 	}
 }
 
-// Generate creates the markdown ToC from a tree.
+// Generate creates the ToC from a tree in the configured format.
 func (g *Generator) Generate(tree *Tree) string {
+	switch g.config.Format {
+	case FormatJSON:
+		return g.generateJSON(tree)
+	case FormatDetails:
+		return g.generateDetails(tree)
+	case FormatHTML:
+		return g.generateHTML(tree)
+	case FormatMkDocs:
+		return g.generateMkDocs(tree)
+	case FormatDocusaurus:
+		return g.generateDocusaurus(tree)
+	case FormatMdBook:
+		return g.generateMdBook(tree)
+	case FormatRST:
+		return g.generateRST(tree)
+	case FormatAsciiDoc:
+		return g.generateAsciiDoc(tree)
+	case FormatTerm:
+		return g.generateTerm(tree)
+	}
+
 	if g.config.Fancy {
 		return g.generateFancy(tree)
 	}
 	return g.generateASCII(tree)
 }
 
+// summaryFor returns the summary of a file node, preferring the node's own
+// summary over the configured summaries map.
+func (g *Generator) summaryFor(node *Node) string {
+	if node.Summary != "" {
+		return node.Summary
+	}
+	return g.config.Summaries[node.Path]
+}
+
+// tagsFor returns the frontmatter tags of a node followed by its extracted
+// keywords, without duplicates.
+func tagsFor(node *Node) []string {
+	seen := make(map[string]bool, len(node.Tags)+len(node.Keywords))
+	var tags []string
+	for _, tag := range append(append([]string{}, node.Tags...), node.Keywords...) {
+		key := strings.ToLower(tag)
+		if !seen[key] {
+			seen[key] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// overflowEntry renders an overflow node as a link to its directory, or as
+// plain text when it has nowhere to link to.
+func overflowEntry(node *Node) string {
+	if link := node.link(); link != "" {
+		return fmt.Sprintf("[%s](%s)", node.Name, link)
+	}
+	return node.Name
+}
+
+// icon returns a node's fancy icon followed by a space, or "" if it has none.
+func (g *Generator) icon(node *Node) string {
+	if icon := g.config.Icons.Icon(node); icon != "" {
+		return icon + " "
+	}
+	return ""
+}
+
 // generateASCII creates ASCII tree style output.
 // Prefixes use &nbsp; instead of plain spaces so indentation survives
 // markdown rendering, and each tree line ends with two trailing spaces
@@ -81,25 +201,32 @@ func (g *Generator) generateASCII(tree *Tree) string {
 		// Write the entry
 		sb.WriteString(linePrefix)
 
+		if g.config.GenerateAnchors {
+			fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
+		}
+
 		if node.IsDir {
-			if g.config.GenerateAnchors {
-				fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
+			// Directories link to their promoted index file, if any
+			if link := node.link(); link != "" {
+				fmt.Fprintf(&sb, "[%s/](%s)%s%s  \n", node.Name, link, linkMarker(node), g.markdownBadges(node))
+			} else {
+				sb.WriteString(node.Name)
+				sb.WriteString("/")
+				sb.WriteString(linkMarker(node))
+				sb.WriteString("  \n")
 			}
-			sb.WriteString(node.Name)
-			sb.WriteString("/  \n")
+		} else if node.Overflow > 0 {
+			sb.WriteString(overflowEntry(node))
+			sb.WriteString("  \n")
 		} else {
-			if g.config.GenerateAnchors {
-				fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
-			}
-			fmt.Fprintf(&sb, "[%s](%s)  \n", node.Name, node.Path)
+			sb.WriteString(g.fileEntry(node))
+			sb.WriteString("  \n")
+		}
 
+		if doc := node.document(); doc != nil {
 			// Add summary if enabled
 			if g.config.IncludeSummary {
-				summary := node.Summary
-				if summary == "" {
-					summary = g.config.Summaries[node.Path]
-				}
-				if summary != "" {
+				if summary := g.summaryFor(doc); summary != "" {
 					summaryPrefix := mdSafePrefix(buildContinuationPrefix(isLastAtLevel, isLast))
 					sb.WriteString(summaryPrefix)
 					sb.WriteString("> ")
@@ -107,6 +234,16 @@ func (g *Generator) generateASCII(tree *Tree) string {
 					sb.WriteString("  \n")
 				}
 			}
+
+			// Add tag line if enabled
+			if g.config.IncludeTags {
+				if tags := tagsFor(doc); len(tags) > 0 {
+					sb.WriteString(mdSafePrefix(buildContinuationPrefix(isLastAtLevel, isLast)))
+					sb.WriteString("> Tags: ")
+					sb.WriteString(strings.Join(tags, ", "))
+					sb.WriteString("  \n")
+				}
+			}
 		}
 
 		// Track this level for children
@@ -115,6 +252,8 @@ func (g *Generator) generateASCII(tree *Tree) string {
 		}
 	})
 
+	sb.WriteString(g.recentSection(tree))
+
 	return sb.String()
 }
 
@@ -176,46 +315,61 @@ func (g *Generator) generateFancy(tree *Tree) string {
 
 		sb.WriteString(indent)
 
+		sb.WriteString("- ")
+		if g.config.GenerateAnchors {
+			fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
+		}
+
 		if node.IsDir {
-			// Directory with folder emoji
-			sb.WriteString("- ")
-			if g.config.GenerateAnchors {
-				fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
+			// Directory with its icon, linked to its promoted index file
+			sb.WriteString(g.icon(node))
+			sb.WriteString("**")
+			if link := node.link(); link != "" {
+				fmt.Fprintf(&sb, "[%s/](%s)", node.Name, link)
+			} else {
+				sb.WriteString(node.Name)
+				sb.WriteString("/")
 			}
-			sb.WriteString(emojiFolder)
-			sb.WriteString(" **")
-			sb.WriteString(node.Name)
-			sb.WriteString("/**\n")
+			sb.WriteString("**")
+			sb.WriteString(linkMarker(node))
+			sb.WriteString(g.markdownBadges(node))
+			sb.WriteString("\n")
+		} else if node.Overflow > 0 {
+			// Hidden entries, linked to their directory
+			sb.WriteString(overflowEntry(node))
+			sb.WriteString("\n")
 		} else {
-			// File with document emoji
-			sb.WriteString("- ")
-			if g.config.GenerateAnchors {
-				fmt.Fprintf(&sb, "<a id=\"%s\"></a>", generateSlug(node.Path))
-			}
-			sb.WriteString(emojiFile)
-			sb.WriteString(" [")
-			sb.WriteString(node.Name)
-			sb.WriteString("](")
-			sb.WriteString(node.Path)
-			sb.WriteString(")\n")
+			// File with its icon
+			sb.WriteString(g.icon(node))
+			sb.WriteString(g.fileEntry(node))
+			sb.WriteString("\n")
+		}
 
+		if doc := node.document(); doc != nil {
 			// Add summary if enabled
 			if g.config.IncludeSummary {
-				summary := node.Summary
-				if summary == "" {
-					summary = g.config.Summaries[node.Path]
-				}
-
-				if summary != "" {
+				if summary := g.summaryFor(doc); summary != "" {
 					sb.WriteString(indent)
 					sb.WriteString("  > 💬 ")
 					sb.WriteString(summary)
 					sb.WriteString("\n")
 				}
 			}
+
+			// Add tag line if enabled
+			if g.config.IncludeTags {
+				if tags := tagsFor(doc); len(tags) > 0 {
+					sb.WriteString(indent)
+					sb.WriteString("  > 🏷️ ")
+					sb.WriteString(strings.Join(tags, ", "))
+					sb.WriteString("\n")
+				}
+			}
 		}
 	})
 
+	sb.WriteString(g.recentSection(tree))
+
 	return sb.String()
 }
 
@@ -239,9 +393,9 @@ func (g *Generator) FormatTree(tree *Tree) string {
 
 // Summary statistics about the generated ToC.
 type Stats struct {
-	TotalFiles       int
-	TotalDirectories int
-	MaxDepth         int
+	TotalFiles       int `json:"total_files"`
+	TotalDirectories int `json:"total_directories"`
+	MaxDepth         int `json:"max_depth"`
 }
 
 // GetStats returns statistics about the tree.
@@ -249,8 +403,15 @@ func GetStats(tree *Tree) Stats {
 	stats := Stats{}
 
 	tree.Walk(func(node *Node, depth int, isLast bool) {
+		if node.Overflow > 0 {
+			// Hidden entries are not part of the rendered tree
+			return
+		}
 		if node.IsDir {
 			stats.TotalDirectories++
+			if node.Index != nil {
+				stats.TotalFiles++
+			}
 		} else {
 			stats.TotalFiles++
 		}
@@ -359,3 +359,30 @@ func TestFormatTreeFancy(t *testing.T) {
 		t.Error("FormatTree fancy output should contain file emoji")
 	}
 }
+
+func TestGeneratorWithTags(t *testing.T) {
+	tree := NewTree("project")
+	node := tree.AddFile("deploy.md")
+	node.Tags = []string{"ops", "Kubernetes"}
+	node.Keywords = []string{"kubernetes", "rollback"}
+	tree.AddFile("plain.md")
+	tree.Sort()
+
+	ascii := NewGenerator(GeneratorConfig{IncludeTags: true}).Generate(tree)
+	if !strings.Contains(ascii, "> Tags: ops, Kubernetes, rollback  \n") {
+		t.Errorf("ASCII output should contain merged tag line, got:\n%s", ascii)
+	}
+	if strings.Count(ascii, "Tags:") != 1 {
+		t.Error("files without tags should not get a tag line")
+	}
+
+	fancy := NewGenerator(GeneratorConfig{IncludeTags: true, Fancy: true}).Generate(tree)
+	if !strings.Contains(fancy, "  > 🏷️ ops, Kubernetes, rollback\n") {
+		t.Errorf("fancy output should contain tag line, got:\n%s", fancy)
+	}
+
+	plain := NewGenerator(GeneratorConfig{}).Generate(tree)
+	if strings.Contains(plain, "Tags:") {
+		t.Error("tag line should only be shown when enabled")
+	}
+}
@@ -0,0 +1,80 @@
+package toc
+
+import "strings"
+
+// UngroupedName is the name of the group holding files without any values
+// for the grouping key.
+const UngroupedName = "untagged"
+
+// GroupBy builds a virtual tree whose top-level directories are the values
+// returned by key for each file, such as its tags. A file is listed under
+// every value it carries; files without values go into an UngroupedName
+// group at the end. Groups are ordered by name (case-insensitive) and files
+// keep their order from the source tree, so sort the source first.
+func GroupBy(tree *Tree, key func(node *Node) []string) *Tree {
+	grouped := NewTree(tree.Root.Name)
+	groups := make(map[string]*Node)
+	var order []*Node
+	var ungrouped *Node
+
+	tree.Walk(func(node *Node, depth int, isLast bool) {
+		if node.IsDir {
+			return
+		}
+
+		values := key(node)
+		if len(values) == 0 {
+			if ungrouped == nil {
+				ungrouped = newGroupNode(UngroupedName)
+			}
+			ungrouped.AddChild(node.groupedCopy())
+			return
+		}
+
+		added := make(map[string]bool, len(values))
+		for _, value := range values {
+			name := strings.TrimSpace(value)
+			id := strings.ToLower(name)
+			if name == "" || added[id] {
+				continue
+			}
+			added[id] = true
+
+			group, exists := groups[id]
+			if !exists {
+				group = newGroupNode(name)
+				groups[id] = group
+				order = append(order, group)
+			}
+			group.AddChild(node.groupedCopy())
+		}
+	})
+
+	sortByName(order)
+	for _, group := range order {
+		grouped.Root.AddChild(group)
+	}
+	if ungrouped != nil {
+		grouped.Root.AddChild(ungrouped)
+	}
+
+	return grouped
+}
+
+// newGroupNode creates a virtual directory node for a group.
+func newGroupNode(name string) *Node {
+	node := NewNode(name, name, true)
+	node.Virtual = true
+	return node
+}
+
+// groupedCopy returns a copy of a file node for use in a grouped tree. The
+// copy is named after its full path, since files from different
+// directories may share a name within a group.
+func (n *Node) groupedCopy() *Node {
+	clone := *n
+	clone.Name = n.Path
+	clone.Children = make([]*Node, 0)
+	clone.childIndex = make(map[string]*Node)
+	return &clone
+}
@@ -0,0 +1,64 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("ops/deploy.md").Tags = []string{"ops", "Release"}
+	tree.AddFile("dev/setup.md").Tags = []string{"onboarding"}
+	tree.AddFile("dev/release.md").Tags = []string{"release", "release"}
+	tree.AddFile("README.md")
+	tree.Sort()
+
+	grouped := GroupBy(tree, func(node *Node) []string { return node.Tags })
+
+	var names []string
+	for _, group := range grouped.Root.Children {
+		if !group.IsDir || !group.Virtual {
+			t.Errorf("group %s should be a virtual directory", group.Name)
+		}
+		var files []string
+		for _, file := range group.Children {
+			files = append(files, file.Name)
+		}
+		names = append(names, group.Name+":"+strings.Join(files, ","))
+	}
+
+	// The first spelling seen (in tree order) names a group
+	expected := []string{
+		"onboarding:dev/setup.md",
+		"ops:ops/deploy.md",
+		"release:dev/release.md,ops/deploy.md",
+		"untagged:README.md",
+	}
+	if strings.Join(names, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected groups %v, got %v", expected, names)
+	}
+
+	// Copies keep the original path for linking and leave the source intact
+	copy := grouped.Root.Children[0].Children[0]
+	if copy.Path != "dev/setup.md" {
+		t.Errorf("expected path dev/setup.md, got %s", copy.Path)
+	}
+	if tree.Find("dev/setup.md").Name != "setup.md" {
+		t.Error("grouping should not modify the source tree")
+	}
+}
+
+func TestGroupByRender(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("docs/guide.md").Tags = []string{"onboarding"}
+
+	grouped := GroupBy(tree, func(node *Node) []string { return node.Tags })
+	output := NewGenerator(GeneratorConfig{}).Generate(grouped)
+
+	if !strings.Contains(output, "onboarding/  \n") {
+		t.Errorf("output should contain group directory, got:\n%s", output)
+	}
+	if !strings.Contains(output, "[docs/guide.md](docs/guide.md)") {
+		t.Errorf("output should link to the original path, got:\n%s", output)
+	}
+}
@@ -0,0 +1,92 @@
+package toc
+
+import (
+	_ "embed"
+	"html/template"
+	"strings"
+)
+
+//go:embed templates/index.html.tmpl
+var htmlTemplateText string
+
+// htmlTemplate renders the standalone HTML page. Styles and scripts are
+// inlined so the page works offline and without any CDN.
+var htmlTemplate = template.Must(template.New("index").Parse(htmlTemplateText))
+
+// htmlPage is the data passed to the HTML template.
+type htmlPage struct {
+	Title string
+	Root  string
+	Stats Stats
+	Nodes []*htmlNode
+}
+
+// htmlNode is a tree node prepared for the HTML template.
+type htmlNode struct {
+	Name     string
+	Anchor   string   // Element ID of a directory, for sidebar links
+	Link     string   // File, index or directory to link to ("" = no link)
+	Dir      bool     // Directory rendered as a collapsible block
+	Open     bool     // Directory starts expanded
+	Overflow bool     // "… and N more" node
+	Change   string   // Git change: added, modified or deleted
+	Symlink  bool     // Reached through a symbolic link
+	Summary  string   // Summary, if enabled
+	Tags     []string // Tags and keywords, if enabled
+	Badges   []string // Status and staleness badges, if enabled
+	Children []*htmlNode
+}
+
+// generateHTML creates a self-contained HTML index page with a sidebar of
+// directories, a collapsible tree and a client-side filter box. Directories
+// shallower than OpenDepth start expanded.
+func (g *Generator) generateHTML(tree *Tree) string {
+	page := htmlPage{
+		Title: g.config.Title,
+		Root:  tree.Root.Name,
+		Stats: GetStats(tree),
+		Nodes: g.htmlNodes(tree.Root.Children, 0),
+	}
+
+	var sb strings.Builder
+	// The template and data are fixed, so this cannot fail
+	_ = htmlTemplate.Execute(&sb, page)
+	return sb.String()
+}
+
+// htmlNodes converts nodes and their descendants for the HTML template.
+func (g *Generator) htmlNodes(nodes []*Node, depth int) []*htmlNode {
+	result := make([]*htmlNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		hn := &htmlNode{
+			Name:     node.Name,
+			Link:     node.link(),
+			Dir:      node.IsDir,
+			Overflow: node.Overflow > 0,
+			Change:   node.Change,
+			Symlink:  node.Symlink,
+		}
+
+		if node.IsDir {
+			hn.Name += "/"
+			hn.Anchor = "dir-" + generateSlug(node.Path)
+			hn.Open = depth < g.config.OpenDepth
+			hn.Children = g.htmlNodes(node.Children, depth+1)
+		}
+
+		if doc := node.document(); doc != nil {
+			if g.config.IncludeSummary {
+				hn.Summary = g.summaryFor(doc)
+			}
+			if g.config.IncludeTags {
+				hn.Tags = tagsFor(doc)
+			}
+			hn.Badges = g.badgesFor(doc)
+		}
+
+		result = append(result, hn)
+	}
+
+	return result
+}
@@ -0,0 +1,68 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratorHTML(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("docs/guide.md")
+	tree.AddFile("docs/api/handlers.md").Tags = []string{"api"}
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{
+		Title:          "Docs <Index>",
+		Format:         FormatHTML,
+		IncludeSummary: true,
+		IncludeTags:    true,
+		OpenDepth:      1,
+		Summaries:      map[string]string{"README.md": "Overview & <intro>"},
+	})
+	output := gen.Generate(tree)
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Docs &lt;Index&gt;</title>",
+		`<a href="#dir-docs">docs/</a>`,
+		`<details id="dir-docs" open><summary class="dir">docs/</summary>`,
+		`<details id="dir-docs-api"><summary class="dir">api/</summary>`,
+		`<a href="docs/guide.md">guide.md</a>`,
+		`<div class="summary">Overview &amp; &lt;intro&gt;</div>`,
+		`<div class="tags"><span>api</span></div>`,
+		`id="filter"`,
+		"3 files, 2 directories",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in HTML output", want)
+		}
+	}
+
+	// The page must be usable offline
+	for _, external := range []string{"<link ", "src=\"http", "href=\"http"} {
+		if strings.Contains(output, external) {
+			t.Errorf("HTML output should not reference external assets (%q)", external)
+		}
+	}
+}
+
+func TestGeneratorHTMLLinks(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("docs/README.md")
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		tree.AddFile("docs/" + name)
+	}
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+	tree.LimitChildren(1)
+
+	output := NewGenerator(GeneratorConfig{Format: FormatHTML}).Generate(tree)
+
+	if !strings.Contains(output, `<summary class="dir"><a href="docs/README.md">docs/</a></summary>`) {
+		t.Error("directories should link to their promoted index")
+	}
+	if !strings.Contains(output, `<li class="more"><a href="docs/">… and 2 more</a></li>`) {
+		t.Error("overflow nodes should link to their directory")
+	}
+}
@@ -0,0 +1,199 @@
+package toc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// IconSet maps nodes to the icons shown in fancy output. Lookups are
+// case-insensitive. A file's icon is taken from the first match of its
+// status, its name, then its extension; a directory's from its name.
+type IconSet struct {
+	Folder      string            `json:"folder"`      // Default directory icon
+	File        string            `json:"file"`        // Default file icon
+	Status      map[string]string `json:"status"`      // By frontmatter status, e.g. "draft"
+	Names       map[string]string `json:"names"`       // By file name, with or without extension, e.g. "README"
+	Extensions  map[string]string `json:"extensions"`  // By extension, e.g. ".md"
+	Directories map[string]string `json:"directories"` // By directory name, e.g. "docs"
+}
+
+// DefaultIconTheme is the theme used when none is configured.
+const DefaultIconTheme = "emoji"
+
+// IconThemes are the built-in icon sets.
+var IconThemes = map[string]IconSet{
+	// The default theme keeps the classic folder and file icons for plain
+	// entries, so existing fancy output only changes for special files
+	"emoji": {
+		Folder: emojiFolder,
+		File:   emojiFile,
+		Status: map[string]string{
+			"draft":      "🚧",
+			"deprecated": "⚠️",
+			"archived":   "📦",
+		},
+		Names: map[string]string{
+			"changelog":    "📝",
+			"license":      "⚖️",
+			"contributing": "🤝",
+			"security":     "🔒",
+		},
+	},
+	// Nerd Font glyphs (Font Awesome and Devicons ranges)
+	"nerd": {
+		Folder: "\uf07b",
+		File:   "\uf15c",
+		Status: map[string]string{
+			"draft":      "\uf040",
+			"deprecated": "\uf071",
+			"archived":   "\uf187",
+		},
+		Names: map[string]string{
+			"readme":       "\uf02d",
+			"changelog":    "\uf1da",
+			"license":      "\uf24e",
+			"contributing": "\uf0c0",
+			"security":     "\uf023",
+		},
+		Extensions: map[string]string{
+			".md":       "\ue73e",
+			".markdown": "\ue73e",
+			".mdx":      "\ue73e",
+		},
+		Directories: map[string]string{
+			"docs":     "\uf02d",
+			"api":      "\uf1e6",
+			"examples": "\uf0eb",
+			"tests":    "\uf0c3",
+			"assets":   "\uf03e",
+			".github":  "\uf09b",
+		},
+	},
+	// Plain text markers for fonts and terminals without emoji
+	"text": {
+		Folder: "[dir]",
+		File:   "[doc]",
+		Status: map[string]string{
+			"draft":      "[draft]",
+			"deprecated": "[deprecated]",
+			"archived":   "[archived]",
+		},
+		Names: map[string]string{
+			"readme":    "[readme]",
+			"changelog": "[changes]",
+			"license":   "[license]",
+		},
+	},
+}
+
+// IconTheme returns a copy of a built-in icon set by name.
+func IconTheme(name string) (*IconSet, error) {
+	theme, ok := IconThemes[name]
+	if !ok {
+		names := make([]string, 0, len(IconThemes))
+		for n := range IconThemes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown icon theme %q (expected %s)", name, strings.Join(names, ", "))
+	}
+
+	var set IconSet
+	set.merge(&theme)
+	return &set, nil
+}
+
+// LoadIconSet reads a JSON icon configuration and merges it over a
+// built-in theme. The file may name its base theme with a "theme" key,
+// which takes precedence over baseTheme:
+//
+//	{"theme": "nerd", "names": {"RUNBOOK": "🚨"}, "directories": {"adr": "🏛️"}}
+func LoadIconSet(filename, baseTheme string) (*IconSet, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var config struct {
+		Theme string `json:"theme"`
+		IconSet
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	if config.Theme != "" {
+		baseTheme = config.Theme
+	}
+	set, err := IconTheme(baseTheme)
+	if err != nil {
+		return nil, err
+	}
+	set.merge(&config.IconSet)
+	return set, nil
+}
+
+// merge copies the icons configured in other over s.
+func (s *IconSet) merge(other *IconSet) {
+	if other.Folder != "" {
+		s.Folder = other.Folder
+	}
+	if other.File != "" {
+		s.File = other.File
+	}
+	s.Status = mergeIcons(s.Status, other.Status)
+	s.Names = mergeIcons(s.Names, other.Names)
+	s.Extensions = mergeIcons(s.Extensions, other.Extensions)
+	s.Directories = mergeIcons(s.Directories, other.Directories)
+}
+
+// mergeIcons returns a new map holding dst overlaid with src. Keys are
+// stored lowercase so lookups are case-insensitive.
+func mergeIcons(dst, src map[string]string) map[string]string {
+	merged := make(map[string]string, len(dst)+len(src))
+	for k, v := range dst {
+		merged[strings.ToLower(k)] = v
+	}
+	for k, v := range src {
+		merged[strings.ToLower(k)] = v
+	}
+	return merged
+}
+
+// Icon returns the icon for a node. Overflow nodes have no icon.
+func (s *IconSet) Icon(node *Node) string {
+	if node.Overflow > 0 {
+		return ""
+	}
+
+	name := strings.ToLower(node.Name)
+	if node.IsDir {
+		// Compacted chains ("a/b/c") are named after their last directory
+		if icon, ok := s.Directories[path.Base(name)]; ok {
+			return icon
+		}
+		return s.Folder
+	}
+
+	if icon, ok := s.Status[strings.ToLower(node.Status)]; ok && node.Status != "" {
+		return icon
+	}
+
+	// Grouped copies are named by their full path
+	base := path.Base(name)
+	ext := path.Ext(base)
+	if icon, ok := s.Names[base]; ok {
+		return icon
+	}
+	if icon, ok := s.Names[strings.TrimSuffix(base, ext)]; ok {
+		return icon
+	}
+	if icon, ok := s.Extensions[ext]; ok {
+		return icon
+	}
+	return s.File
+}
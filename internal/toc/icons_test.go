@@ -0,0 +1,131 @@
+package toc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIconSetIcon(t *testing.T) {
+	set, err := IconTheme("text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.merge(&IconSet{
+		Extensions:  map[string]string{".MDX": "[mdx]"},
+		Directories: map[string]string{"API": "[api]"},
+	})
+
+	tests := []struct {
+		node *Node
+		want string
+	}{
+		{&Node{Name: "guides", IsDir: true}, "[dir]"},
+		{&Node{Name: "api", IsDir: true}, "[api]"},
+		{&Node{Name: "src/api", IsDir: true}, "[api]"},
+		{&Node{Name: "guide.md"}, "[doc]"},
+		{&Node{Name: "README.md"}, "[readme]"},
+		{&Node{Name: "CHANGELOG"}, "[changes]"},
+		{&Node{Name: "widget.mdx"}, "[mdx]"},
+		{&Node{Name: "README.md", Status: "Draft"}, "[draft]"},
+		{&Node{Name: "guide.md", Status: "unknown"}, "[doc]"},
+		{&Node{Name: "… and 3 more", Overflow: 3}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := set.Icon(tt.node); got != tt.want {
+			t.Errorf("Icon(%q, status %q) = %q, want %q", tt.node.Name, tt.node.Status, got, tt.want)
+		}
+	}
+}
+
+func TestIconTheme(t *testing.T) {
+	for name := range IconThemes {
+		set, err := IconTheme(name)
+		if err != nil {
+			t.Fatalf("IconTheme(%q) failed: %v", name, err)
+		}
+		if set.Folder == "" || set.File == "" {
+			t.Errorf("theme %q should define folder and file icons", name)
+		}
+	}
+
+	// Themes are copies, so changes do not leak into the built-ins
+	set, _ := IconTheme("emoji")
+	set.Status["draft"] = "x"
+	if IconThemes["emoji"].Status["draft"] == "x" {
+		t.Error("IconTheme should return a copy")
+	}
+
+	if _, err := IconTheme("unknown"); err == nil || !strings.Contains(err.Error(), "emoji, nerd, text") {
+		t.Errorf("expected error listing themes, got %v", err)
+	}
+}
+
+func TestLoadIconSet(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-icons-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	filename := filepath.Join(tmpDir, "icons.json")
+	config := `{"folder": "D", "names": {"RUNBOOK": "R"}}`
+	if err := os.WriteFile(filename, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadIconSet(filename, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Folder != "D" || set.File != "[doc]" {
+		t.Errorf("expected overrides on top of the text theme, got folder %q file %q", set.Folder, set.File)
+	}
+	if got := set.Icon(&Node{Name: "runbook.md"}); got != "R" {
+		t.Errorf("expected configured name icon, got %q", got)
+	}
+	if got := set.Icon(&Node{Name: "README.md"}); got != "[readme]" {
+		t.Errorf("expected base theme name icon, got %q", got)
+	}
+
+	// The file's own theme key wins over the base theme
+	if err := os.WriteFile(filename, []byte(`{"theme": "emoji"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if set, err = LoadIconSet(filename, "text"); err != nil || set.Folder != emojiFolder {
+		t.Errorf("expected emoji theme from file, got %+v, %v", set, err)
+	}
+
+	if err := os.WriteFile(filename, []byte(`{"theme": "neon"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadIconSet(filename, "text"); err == nil {
+		t.Error("expected error for unknown theme in file")
+	}
+
+	if err := os.WriteFile(filename, []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadIconSet(filename, "text"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestGeneratorFancyIcons(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("CHANGELOG.md")
+	tree.AddFile("docs/guide.md")
+	tree.Find("docs/guide.md").Status = "deprecated"
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{Title: "Docs", Fancy: true})
+	result := gen.Generate(tree)
+
+	for _, want := range []string{"📁 **docs/**", "⚠️ [guide.md](docs/guide.md)", "📝 [CHANGELOG.md](CHANGELOG.md)"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package toc
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultIndexNames are the file names that represent their directory when
+// index promotion is enabled, in order of preference.
+var DefaultIndexNames = []string{"README.md", "index.md", "README.markdown", "index.markdown"}
+
+// PromoteIndex attaches each directory's index file (the first child whose
+// name matches names, case-insensitively) to the directory itself and
+// removes it from the children, so the directory entry links to it instead
+// of listing it again. The root and virtual directories are left alone,
+// since they have no entry of their own to link from.
+func (t *Tree) PromoteIndex(names []string) {
+	for _, child := range t.Root.Children {
+		child.promoteIndex(names)
+	}
+}
+
+// promoteIndex promotes index files for this node and its descendants.
+func (n *Node) promoteIndex(names []string) {
+	if !n.IsDir {
+		return
+	}
+
+	for _, child := range n.Children {
+		child.promoteIndex(names)
+	}
+
+	if n.Virtual || n.Index != nil {
+		return
+	}
+
+	for _, name := range names {
+		idx := slices.IndexFunc(n.Children, func(child *Node) bool {
+			return !child.IsDir && strings.EqualFold(child.Name, name)
+		})
+		if idx == -1 {
+			continue
+		}
+
+		n.Index = n.Children[idx]
+		n.Children = slices.Delete(n.Children, idx, idx+1)
+		delete(n.childIndex, n.Index.Name)
+		return
+	}
+}
+
+// link returns the path a node's entry should link to: the file itself, a
+// directory's promoted index file, or the directory holding the entries of
+// an overflow node. Returns "" for directories without an index.
+func (n *Node) link() string {
+	if n.Overflow > 0 {
+		if n.Path == "" {
+			return ""
+		}
+		return n.Path + "/"
+	}
+	if n.deleted() {
+		return ""
+	}
+	if !n.IsDir {
+		return n.Path
+	}
+	if n.Index != nil {
+		return n.Index.Path
+	}
+	return ""
+}
+
+// document returns the file node holding a node's content: the node itself
+// for files, or a directory's promoted index file (nil if none).
+func (n *Node) document() *Node {
+	if n.Overflow > 0 {
+		return nil
+	}
+	if !n.IsDir {
+		return n
+	}
+	return n.Index
+}
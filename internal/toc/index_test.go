@@ -0,0 +1,92 @@
+package toc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPromoteIndex(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("docs/readme.md")
+	tree.AddFile("docs/index.md")
+	tree.AddFile("docs/guide.md")
+	tree.AddFile("docs/api/index.md")
+	tree.AddFile("notes/todo.md")
+	tree.Sort()
+
+	tree.PromoteIndex(DefaultIndexNames)
+
+	// The root has no entry of its own, so its README stays listed
+	if tree.Find("README.md") == nil {
+		t.Error("root README.md should not be promoted")
+	}
+
+	docs := tree.Find("docs")
+	if docs.Index == nil || docs.Index.Path != "docs/readme.md" {
+		t.Fatalf("expected docs/readme.md to be promoted (preferred over index.md), got %+v", docs.Index)
+	}
+	if result := childNames(docs); result != "api guide.md index.md" {
+		t.Errorf("promoted file should be removed from children, got %q", result)
+	}
+
+	if api := tree.Find("docs/api"); api.Index == nil || len(api.Children) != 0 {
+		t.Errorf("expected docs/api/index.md to be promoted, got %+v", api)
+	}
+	if tree.Find("notes").Index != nil {
+		t.Error("directories without an index file should be unchanged")
+	}
+}
+
+func TestPromoteIndexSkipsVirtualGroups(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md").Tags = []string{"intro"}
+
+	grouped := GroupBy(tree, func(node *Node) []string { return node.Tags })
+	grouped.PromoteIndex([]string{"README.md"})
+
+	if grouped.Root.Children[0].Index != nil {
+		t.Error("virtual groups should not get an index file")
+	}
+}
+
+func TestGeneratorPromotedIndex(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("docs/README.md")
+	tree.AddFile("docs/guide.md")
+	tree.AddFile("empty/notes.md")
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+
+	summaries := map[string]string{"docs/README.md": "All the docs."}
+
+	ascii := NewGenerator(GeneratorConfig{IncludeSummary: true, Summaries: summaries}).Generate(tree)
+	for _, want := range []string{"[docs/](docs/README.md)  \n", "│&nbsp;&nbsp;&nbsp;> All the docs.  \n", "empty/  \n"} {
+		if !strings.Contains(ascii, want) {
+			t.Errorf("ASCII output should contain %q, got:\n%s", want, ascii)
+		}
+	}
+	if strings.Contains(ascii, "[README.md]") {
+		t.Error("promoted file should not be listed again")
+	}
+
+	fancy := NewGenerator(GeneratorConfig{IncludeSummary: true, Summaries: summaries, Fancy: true}).Generate(tree)
+	for _, want := range []string{"- 📁 **[docs/](docs/README.md)**\n", "  > 💬 All the docs.\n", "- 📁 **empty/**\n"} {
+		if !strings.Contains(fancy, want) {
+			t.Errorf("fancy output should contain %q, got:\n%s", want, fancy)
+		}
+	}
+
+	var doc jsonDocument
+	jsonOutput := NewGenerator(GeneratorConfig{Format: FormatJSON, IncludeSummary: true, Summaries: summaries}).Generate(tree)
+	if err := json.Unmarshal([]byte(jsonOutput), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc.Children[0].Index != "docs/README.md" || doc.Children[0].Summary != "All the docs." {
+		t.Errorf("JSON directory should carry its index, got %+v", doc.Children[0])
+	}
+	if doc.Stats.TotalFiles != 3 {
+		t.Errorf("promoted files should still be counted, got %d", doc.Stats.TotalFiles)
+	}
+}
@@ -0,0 +1,105 @@
+package toc
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// jsonDocument is the top-level JSON output.
+type jsonDocument struct {
+	Title    string      `json:"title"`
+	Root     string      `json:"root"`
+	Stats    Stats       `json:"stats"`
+	Children []*jsonNode `json:"children"`
+	Recent   []string    `json:"recent,omitempty"` // Paths of recently updated files, if enabled
+}
+
+// jsonNode is the JSON representation of a tree node.
+type jsonNode struct {
+	Name       string      `json:"name"`
+	Path       string      `json:"path"`
+	Type       string      `json:"type"`
+	Index      string      `json:"index,omitempty"`
+	Title      string      `json:"title,omitempty"`
+	Summary    string      `json:"summary,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+	Keywords   []string    `json:"keywords,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	LastCommit string      `json:"lastCommit,omitempty"`
+	LastAuthor string      `json:"lastAuthor,omitempty"`
+	Commits    int         `json:"commits,omitempty"`
+	Change     string      `json:"change,omitempty"`
+	Symlink    bool        `json:"symlink,omitempty"`
+	Badges     []string    `json:"badges,omitempty"`
+	Hidden     int         `json:"hidden,omitempty"`
+	Children   []*jsonNode `json:"children,omitempty"`
+}
+
+// generateJSON creates a JSON tree. Summaries and tags are included when
+// enabled, exactly as in the markdown output.
+func (g *Generator) generateJSON(tree *Tree) string {
+	doc := jsonDocument{
+		Title:    g.config.Title,
+		Root:     tree.Root.Name,
+		Stats:    GetStats(tree),
+		Children: g.jsonNodes(tree.Root.Children),
+	}
+	for _, file := range RecentlyUpdated(tree, g.config.Recent) {
+		doc.Recent = append(doc.Recent, file.Path)
+	}
+
+	// Only strings, ints and slices are marshaled, so this cannot fail
+	out, _ := json.MarshalIndent(doc, "", "  ")
+	return string(out) + "\n"
+}
+
+// jsonNodes converts nodes and their descendants to JSON nodes.
+func (g *Generator) jsonNodes(nodes []*Node) []*jsonNode {
+	result := make([]*jsonNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		jn := &jsonNode{
+			Name:    node.Name,
+			Path:    node.Path,
+			Type:    "file",
+			Title:   node.Title,
+			Change:  node.Change,
+			Symlink: node.Symlink,
+		}
+
+		if node.IsDir {
+			jn.Type = "dir"
+			jn.Children = g.jsonNodes(node.Children)
+		}
+		if node.Overflow > 0 {
+			jn.Type = "overflow"
+			jn.Hidden = node.Overflow
+		}
+
+		// A directory carries the details of its promoted index file
+		if doc := node.document(); doc != nil {
+			if node.IsDir {
+				jn.Index = doc.Path
+				jn.Title = doc.Title
+			}
+			if g.config.IncludeSummary {
+				jn.Summary = g.summaryFor(doc)
+			}
+			if g.config.IncludeTags {
+				jn.Tags = doc.Tags
+				jn.Keywords = doc.Keywords
+			}
+			jn.Status = doc.Status
+			jn.LastAuthor = doc.LastAuthor
+			jn.Commits = doc.Commits
+			if !doc.LastCommit.IsZero() {
+				jn.LastCommit = doc.LastCommit.UTC().Format(time.RFC3339)
+			}
+			jn.Badges = g.badgesFor(doc)
+		}
+
+		result = append(result, jn)
+	}
+
+	return result
+}
@@ -0,0 +1,78 @@
+package toc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeneratorJSON(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	guide := tree.AddFile("docs/guide.md")
+	guide.Title = "Guide"
+	guide.Tags = []string{"onboarding"}
+	guide.Keywords = []string{"install"}
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{
+		Title:          "Docs",
+		Format:         FormatJSON,
+		IncludeSummary: true,
+		IncludeTags:    true,
+		Summaries:      map[string]string{"docs/guide.md": "Getting started."},
+	})
+
+	var doc jsonDocument
+	if err := json.Unmarshal([]byte(gen.Generate(tree)), &doc); err != nil {
+		t.Fatalf("output should be valid JSON: %v", err)
+	}
+
+	if doc.Title != "Docs" || doc.Root != "project" {
+		t.Errorf("unexpected header: %+v", doc)
+	}
+	if doc.Stats.TotalFiles != 2 || doc.Stats.TotalDirectories != 1 {
+		t.Errorf("unexpected stats: %+v", doc.Stats)
+	}
+	if len(doc.Children) != 2 || doc.Children[0].Type != "dir" {
+		t.Fatalf("expected docs dir first, got %+v", doc.Children)
+	}
+
+	file := doc.Children[0].Children[0]
+	if file.Path != "docs/guide.md" || file.Type != "file" || file.Title != "Guide" {
+		t.Errorf("unexpected file node: %+v", file)
+	}
+	if file.Summary != "Getting started." {
+		t.Errorf("expected summary, got %q", file.Summary)
+	}
+	if len(file.Tags) != 1 || len(file.Keywords) != 1 {
+		t.Errorf("expected tags and keywords, got %+v", file)
+	}
+}
+
+func TestGeneratorJSONWithoutOptionalFields(t *testing.T) {
+	tree := NewTree("project")
+	node := tree.AddFile("README.md")
+	node.Tags = []string{"ignored"}
+
+	gen := NewGenerator(GeneratorConfig{Format: FormatJSON})
+
+	var doc jsonDocument
+	if err := json.Unmarshal([]byte(gen.Generate(tree)), &doc); err != nil {
+		t.Fatalf("output should be valid JSON: %v", err)
+	}
+	if len(doc.Children[0].Tags) != 0 || doc.Children[0].Summary != "" {
+		t.Errorf("tags and summaries should be omitted unless enabled, got %+v", doc.Children[0])
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat(""); err != nil || format != FormatMarkdown {
+		t.Errorf("empty format should default to markdown, got %q (%v)", format, err)
+	}
+	if format, err := ParseFormat("json"); err != nil || format != FormatJSON {
+		t.Errorf("expected json format, got %q (%v)", format, err)
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
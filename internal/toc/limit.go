@@ -0,0 +1,47 @@
+package toc
+
+import "fmt"
+
+// LimitChildren keeps at most max entries in every directory, in their
+// current order, and replaces the rest with a single overflow node such as
+// "… and 243 more" that links to the directory. Sort the tree first so the
+// entries kept are the ones that matter. A max of 0 or less is a no-op.
+func (t *Tree) LimitChildren(max int) {
+	if max <= 0 {
+		return
+	}
+	t.Root.limitChildren(max)
+}
+
+// limitChildren limits this node and its descendants.
+func (n *Node) limitChildren(max int) {
+	for _, child := range n.Children {
+		if child.IsDir {
+			child.limitChildren(max)
+		}
+	}
+
+	if len(n.Children) <= max {
+		return
+	}
+
+	hidden := n.Children[max:]
+	for _, child := range hidden {
+		delete(n.childIndex, child.Name)
+	}
+	n.Children = append(n.Children[:max:max], newOverflowNode(n, hidden))
+}
+
+// newOverflowNode creates the synthetic node standing in for the hidden
+// entries of dir. Virtual directories have nothing on disk to link to, so
+// their overflow node gets no path.
+func newOverflowNode(dir *Node, hidden []*Node) *Node {
+	path := dir.Path
+	if dir.Virtual {
+		path = ""
+	}
+	node := NewNode(fmt.Sprintf("… and %d more", len(hidden)), path, false)
+	node.Overflow = len(hidden)
+	node.Omitted = hidden
+	return node
+}
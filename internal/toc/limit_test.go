@@ -0,0 +1,96 @@
+package toc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLimitChildren(t *testing.T) {
+	tree := NewTree("project")
+	for _, name := range []string{"a.md", "b.md", "c.md", "d.md", "e.md"} {
+		tree.AddFile("changelog/" + name)
+	}
+	tree.AddFile("README.md")
+	tree.Sort()
+
+	tree.LimitChildren(2)
+
+	changelog := tree.Find("changelog")
+	if result := childNames(changelog); result != "a.md b.md … and 3 more" {
+		t.Fatalf("unexpected children %q", result)
+	}
+	overflow := changelog.Children[2]
+	if overflow.Overflow != 3 || overflow.link() != "changelog/" {
+		t.Errorf("overflow node should count hidden entries and link to the directory, got %+v", overflow)
+	}
+	if tree.Find("changelog/c.md") != nil {
+		t.Error("hidden entries should no longer be found")
+	}
+
+	// The root has exactly two entries, so nothing is hidden there
+	if result := childNames(tree.Root); result != "changelog README.md" {
+		t.Errorf("unexpected root children %q", result)
+	}
+}
+
+func TestLimitChildrenDisabled(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("a.md")
+	tree.AddFile("b.md")
+
+	tree.LimitChildren(0)
+
+	if len(tree.Root.Children) != 2 {
+		t.Errorf("a limit of 0 should keep all entries, got %d", len(tree.Root.Children))
+	}
+}
+
+func TestLimitChildrenVirtualGroup(t *testing.T) {
+	tree := NewTree("project")
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		tree.AddFile(name).Tags = []string{"release"}
+	}
+
+	grouped := GroupBy(tree, func(node *Node) []string { return node.Tags })
+	grouped.LimitChildren(1)
+
+	output := NewGenerator(GeneratorConfig{}).Generate(grouped)
+	if !strings.Contains(output, "└──&nbsp;… and 2 more  \n") {
+		t.Errorf("overflow in a virtual group should not link anywhere, got:\n%s", output)
+	}
+}
+
+func TestGeneratorOverflow(t *testing.T) {
+	tree := NewTree("project")
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		tree.AddFile("adr/" + name)
+	}
+	tree.Sort()
+	tree.LimitChildren(1)
+
+	ascii := NewGenerator(GeneratorConfig{IncludeSummary: true, Summaries: map[string]string{"adr": "not a file"}}).Generate(tree)
+	if !strings.Contains(ascii, "[… and 2 more](adr/)") {
+		t.Errorf("expected overflow link in ASCII output, got:\n%s", ascii)
+	}
+	if strings.Contains(ascii, "not a file") {
+		t.Errorf("overflow nodes should have no summary, got:\n%s", ascii)
+	}
+
+	fancy := NewGenerator(GeneratorConfig{Fancy: true}).Generate(tree)
+	if !strings.Contains(fancy, "  - [… and 2 more](adr/)\n") {
+		t.Errorf("expected overflow link in fancy output, got:\n%s", fancy)
+	}
+
+	var doc jsonDocument
+	if err := json.Unmarshal([]byte(NewGenerator(GeneratorConfig{Format: FormatJSON}).Generate(tree)), &doc); err != nil {
+		t.Fatal(err)
+	}
+	overflow := doc.Children[0].Children[1]
+	if overflow.Type != "overflow" || overflow.Hidden != 2 || overflow.Path != "adr" {
+		t.Errorf("unexpected JSON overflow node %+v", overflow)
+	}
+	if doc.Stats.TotalFiles != 1 {
+		t.Errorf("stats should only count rendered files, got %+v", doc.Stats)
+	}
+}
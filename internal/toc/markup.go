@@ -0,0 +1,127 @@
+package toc
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// rstEscaper escapes characters with inline meaning in reStructuredText.
+var rstEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "`", "\\`", "_", `\_`, "|", `\|`, "<", `\<`)
+
+// adocEscaper escapes characters that would end AsciiDoc link text early.
+var adocEscaper = strings.NewReplacer("]", `\]`)
+
+// docName returns a file path without its extension, as used by Sphinx
+// toctrees and :doc: roles.
+func docName(relPath string) string {
+	return strings.TrimSuffix(relPath, path.Ext(relPath))
+}
+
+// generateRST creates a reStructuredText index for Sphinx: a hidden
+// toctree including every document, followed by a nested bullet list with
+// :doc: links that shows the directory structure.
+func (g *Generator) generateRST(tree *Tree) string {
+	var sb strings.Builder
+
+	sb.WriteString(g.config.Title)
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("=", utf8.RuneCountInString(g.config.Title)))
+	sb.WriteString("\n\n.. toctree::\n   :hidden:\n\n")
+
+	tree.Walk(func(node *Node, depth int, isLast bool) {
+		if doc := node.document(); doc != nil && !doc.deleted() {
+			fmt.Fprintf(&sb, "   %s\n", docName(doc.Path))
+		}
+	})
+
+	tree.Walk(func(node *Node, depth int, isLast bool) {
+		// Items are separated by blank lines so nesting is always valid
+		indent := strings.Repeat("  ", depth)
+		sb.WriteString("\n")
+		sb.WriteString(indent)
+		sb.WriteString("- ")
+
+		label := rstEscaper.Replace(node.Name)
+		switch {
+		case node.Overflow > 0:
+			sb.WriteString(label)
+		case node.deleted():
+			fmt.Fprintf(&sb, "%s (deleted)", label)
+		case node.IsDir && node.Index != nil:
+			fmt.Fprintf(&sb, ":doc:`%s/ <%s>`", label, docName(node.Index.Path))
+		case node.IsDir:
+			fmt.Fprintf(&sb, "**%s/**", label)
+		default:
+			fmt.Fprintf(&sb, ":doc:`%s <%s>`", label, docName(node.Path))
+		}
+		sb.WriteString("\n")
+
+		g.writeMarkupNotes(&sb, node.document(), "\n"+indent+"  ", rstEscaper)
+	})
+
+	return sb.String()
+}
+
+// generateAsciiDoc creates an AsciiDoc nested list for Antora and
+// Asciidoctor. Links are xrefs to the .adoc page of the same path, since
+// xref targets must be AsciiDoc documents.
+func (g *Generator) generateAsciiDoc(tree *Tree) string {
+	var sb strings.Builder
+
+	sb.WriteString("= ")
+	sb.WriteString(g.config.Title)
+	sb.WriteString("\n\n")
+
+	tree.Walk(func(node *Node, depth int, isLast bool) {
+		sb.WriteString(strings.Repeat("*", depth+1))
+		sb.WriteString(" ")
+
+		label := adocEscaper.Replace(node.Name)
+		switch {
+		case node.Overflow > 0:
+			sb.WriteString(node.Name)
+		case node.deleted():
+			fmt.Fprintf(&sb, "%s (deleted)", node.Name)
+		case node.IsDir && node.Index != nil:
+			fmt.Fprintf(&sb, "xref:%s.adoc[%s/]", docName(node.Index.Path), label)
+		case node.IsDir:
+			fmt.Fprintf(&sb, "%s/", node.Name)
+		default:
+			fmt.Fprintf(&sb, "xref:%s.adoc[%s]", docName(node.Path), label)
+		}
+		sb.WriteString("\n")
+
+		// A "+" line attaches the following paragraph to the list item
+		g.writeMarkupNotes(&sb, node.document(), "+\n", nil)
+	})
+
+	return sb.String()
+}
+
+// writeMarkupNotes writes the summary and tag paragraphs of a document, if
+// enabled, each preceded by lead and escaped with escaper (if non-nil).
+func (g *Generator) writeMarkupNotes(sb *strings.Builder, doc *Node, lead string, escaper *strings.Replacer) {
+	if doc == nil {
+		return
+	}
+
+	escape := func(s string) string {
+		if escaper == nil {
+			return s
+		}
+		return escaper.Replace(s)
+	}
+
+	if g.config.IncludeSummary {
+		if summary := g.summaryFor(doc); summary != "" {
+			fmt.Fprintf(sb, "%s%s\n", lead, escape(summary))
+		}
+	}
+	if g.config.IncludeTags {
+		if tags := tagsFor(doc); len(tags) > 0 {
+			fmt.Fprintf(sb, "%sTags: %s\n", lead, escape(strings.Join(tags, ", ")))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package toc
+
+import "testing"
+
+// markupTestTree builds a tree with a promoted index, a plain directory
+// and an overflow node.
+func markupTestTree() *Tree {
+	tree := NewTree("docs")
+	tree.AddFile("README.md")
+	tree.AddFile("guides/README.md")
+	tree.AddFile("guides/setup_notes.md")
+	tree.AddFile("reference/a.md")
+	tree.AddFile("reference/b.md")
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+	tree.Find("reference").limitChildren(1)
+	return tree
+}
+
+func TestGeneratorRST(t *testing.T) {
+	gen := NewGenerator(GeneratorConfig{
+		Format:         FormatRST,
+		IncludeSummary: true,
+		Summaries:      map[string]string{"guides/setup_notes.md": "Install *everything*."},
+	})
+	output := gen.Generate(markupTestTree())
+
+	expected := "Table of Contents\n" +
+		"=================\n" +
+		"\n" +
+		".. toctree::\n" +
+		"   :hidden:\n" +
+		"\n" +
+		"   guides/README\n" +
+		"   guides/setup_notes\n" +
+		"   reference/a\n" +
+		"   README\n" +
+		"\n" +
+		"- :doc:`guides/ <guides/README>`\n" +
+		"\n" +
+		"  - :doc:`setup\\_notes.md <guides/setup_notes>`\n" +
+		"\n" +
+		"    Install \\*everything\\*.\n" +
+		"\n" +
+		"- **reference/**\n" +
+		"\n" +
+		"  - :doc:`a.md <reference/a>`\n" +
+		"\n" +
+		"  - … and 1 more\n" +
+		"\n" +
+		"- :doc:`README.md <README>`\n"
+
+	if output != expected {
+		t.Errorf("unexpected RST output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGeneratorAsciiDoc(t *testing.T) {
+	gen := NewGenerator(GeneratorConfig{
+		Title:       "Docs",
+		Format:      FormatAsciiDoc,
+		IncludeTags: true,
+	})
+	tree := markupTestTree()
+	tree.Find("reference/a.md").Tags = []string{"api"}
+	output := gen.Generate(tree)
+
+	expected := `= Docs
+
+* xref:guides/README.adoc[guides/]
+** xref:guides/setup_notes.adoc[setup_notes.md]
+* reference/
+** xref:reference/a.adoc[a.md]
++
+Tags: api
+** … and 1 more
+* xref:README.adoc[README.md]
+`
+	if output != expected {
+		t.Errorf("unexpected AsciiDoc output:\n%s\nwant:\n%s", output, expected)
+	}
+}
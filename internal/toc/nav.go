@@ -0,0 +1,193 @@
+package toc
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// navLabel returns the label of a node in site navigation: a file's title,
+// falling back to its name without extension, or a directory's name.
+func navLabel(node *Node) string {
+	if node.IsDir {
+		return node.Name
+	}
+	if node.Title != "" {
+		return node.Title
+	}
+	return strings.TrimSuffix(node.Name, path.Ext(node.Name))
+}
+
+// navChildren returns the children of a node that belong in site
+// navigation. Overflow nodes are dropped, since nav files have no way to
+// link to "the rest" of a directory, and so are deleted files.
+func navChildren(node *Node) []*Node {
+	children := make([]*Node, 0, len(node.Children))
+	for _, child := range node.Children {
+		if child.Overflow == 0 && !child.deleted() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// generateMkDocs creates the nav block of an mkdocs.yml. Paths are
+// relative to the scanned root, which should be the MkDocs docs_dir.
+// A promoted index page is listed first in its section without a title,
+// which MkDocs themes with section indexes use as the section page.
+func (g *Generator) generateMkDocs(tree *Tree) string {
+	var sb strings.Builder
+	sb.WriteString("nav:\n")
+	writeMkDocsNav(&sb, navChildren(tree.Root), "  ")
+	return sb.String()
+}
+
+// writeMkDocsNav writes nav entries at the given indentation.
+func writeMkDocsNav(sb *strings.Builder, nodes []*Node, indent string) {
+	for _, node := range nodes {
+		if !node.IsDir {
+			fmt.Fprintf(sb, "%s- %s: %s\n", indent, yamlString(navLabel(node)), yamlString(node.Path))
+			continue
+		}
+
+		fmt.Fprintf(sb, "%s- %s:\n", indent, yamlString(navLabel(node)))
+		if node.Index != nil {
+			fmt.Fprintf(sb, "%s    - %s\n", indent, yamlString(node.Index.Path))
+		}
+		writeMkDocsNav(sb, navChildren(node), indent+"    ")
+	}
+}
+
+// yamlString quotes a YAML scalar when it would otherwise be misread.
+func yamlString(s string) string {
+	if s == "" || strings.TrimSpace(s) != s ||
+		strings.ContainsAny(s, ":#{}[],&*!|>'\"%@`\\") ||
+		strings.ContainsAny(s[:1], "-?") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// docusaurusItem is an entry of a Docusaurus sidebar.
+type docusaurusItem struct {
+	Type  string            `json:"type"`
+	ID    string            `json:"id,omitempty"`
+	Label string            `json:"label"`
+	Link  *docusaurusLink   `json:"link,omitempty"`
+	Items []*docusaurusItem `json:"items,omitempty"`
+}
+
+// docusaurusLink points a sidebar category at its index document.
+type docusaurusLink struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// generateDocusaurus creates a Docusaurus sidebars.js with a single "docs"
+// sidebar. Doc IDs are paths without extension relative to the scanned
+// root, which should be the Docusaurus docs directory. The sidebar itself
+// is plain JSON, so it can be copied into a sidebars.json as well.
+func (g *Generator) generateDocusaurus(tree *Tree) string {
+	sidebars := map[string][]*docusaurusItem{
+		"docs": docusaurusItems(navChildren(tree.Root)),
+	}
+
+	// Only strings and slices are marshaled, so this cannot fail
+	out, _ := json.MarshalIndent(sidebars, "", "  ")
+
+	var sb strings.Builder
+	sb.WriteString("// @ts-check\n\n")
+	sb.WriteString("/** @type {import('@docusaurus/plugin-content-docs').SidebarsConfig} */\n")
+	sb.WriteString("const sidebars = ")
+	sb.Write(out)
+	sb.WriteString(";\n\nmodule.exports = sidebars;\n")
+	return sb.String()
+}
+
+// docusaurusItems converts nodes to sidebar items.
+func docusaurusItems(nodes []*Node) []*docusaurusItem {
+	items := make([]*docusaurusItem, 0, len(nodes))
+	for _, node := range nodes {
+		if !node.IsDir {
+			items = append(items, &docusaurusItem{
+				Type:  "doc",
+				ID:    docusaurusID(node),
+				Label: navLabel(node),
+			})
+			continue
+		}
+
+		item := &docusaurusItem{
+			Type:  "category",
+			Label: navLabel(node),
+			Items: docusaurusItems(navChildren(node)),
+		}
+		if node.Index != nil {
+			item.Link = &docusaurusLink{Type: "doc", ID: docusaurusID(node.Index)}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// Docusaurus number prefixes, such as "02-" in "02-setup.md", and the
+// date- and version-like names it leaves alone.
+var (
+	numberPrefixPattern  = regexp.MustCompile(`^\d+\s*[-_.]+\s*([^-_.\s].*)$`)
+	ignoredPrefixPattern = regexp.MustCompile(`^(?:\d{2}|\d{4})[-_.]\d{2}(?:[-_.](?:\d{2}|\d{4}))?|^v?\d+[-_.]\d+`)
+)
+
+// docusaurusID returns the Docusaurus doc ID of a file: its path without
+// extension and with the number prefix of every segment removed, as
+// Docusaurus does by default. A frontmatter id replaces the file name.
+func docusaurusID(node *Node) string {
+	segments := strings.Split(strings.TrimSuffix(node.Path, path.Ext(node.Path)), "/")
+	for i, segment := range segments {
+		segments[i] = stripNumberPrefix(segment)
+	}
+	if node.ID != "" {
+		segments[len(segments)-1] = node.ID
+	}
+	return strings.Join(segments, "/")
+}
+
+// stripNumberPrefix removes a number prefix from a file or directory name.
+func stripNumberPrefix(name string) string {
+	if ignoredPrefixPattern.MatchString(name) {
+		return name
+	}
+	if match := numberPrefixPattern.FindStringSubmatch(name); match != nil {
+		return match[1]
+	}
+	return name
+}
+
+// generateMdBook creates an mdBook SUMMARY.md. Directories without a
+// promoted index page become draft chapters, which mdBook shows in the
+// navigation without a page of their own.
+func (g *Generator) generateMdBook(tree *Tree) string {
+	var sb strings.Builder
+	sb.WriteString("# ")
+	sb.WriteString(g.config.Title)
+	sb.WriteString("\n\n")
+	writeMdBookChapters(&sb, navChildren(tree.Root), "")
+	return sb.String()
+}
+
+// writeMdBookChapters writes nested chapter entries at the given indentation.
+func writeMdBookChapters(sb *strings.Builder, nodes []*Node, indent string) {
+	for _, node := range nodes {
+		label := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(navLabel(node))
+		fmt.Fprintf(sb, "%s- [%s](%s)\n", indent, label, node.link())
+		if node.IsDir {
+			writeMdBookChapters(sb, navChildren(node), indent+"    ")
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package toc
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+)
+
+// navTestTree builds a tree with titles, a promoted index and a directory
+// without one.
+func navTestTree() *Tree {
+	tree := NewTree("docs")
+	tree.AddFile("README.md").Title = "Welcome"
+	tree.AddFile("guides/README.md").Title = "Guides"
+	tree.AddFile("guides/setup.md").Title = "Setup: the basics"
+	tree.AddFile("guides/faq.md")
+	tree.AddFile("reference/api.md").Title = "API [v2]"
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+	return tree
+}
+
+func TestGeneratorMkDocs(t *testing.T) {
+	output := NewGenerator(GeneratorConfig{Format: FormatMkDocs}).Generate(navTestTree())
+
+	expected := `nav:
+  - guides:
+      - guides/README.md
+      - faq: guides/faq.md
+      - "Setup: the basics": guides/setup.md
+  - reference:
+      - "API [v2]": reference/api.md
+  - Welcome: README.md
+`
+	if output != expected {
+		t.Errorf("unexpected mkdocs nav:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGeneratorDocusaurus(t *testing.T) {
+	output := NewGenerator(GeneratorConfig{Format: FormatDocusaurus}).Generate(navTestTree())
+
+	const prefix, suffix = "const sidebars = ", ";\n\nmodule.exports = sidebars;\n"
+	start := strings.Index(output, prefix)
+	if start == -1 || !strings.HasSuffix(output, suffix) {
+		t.Fatalf("expected a sidebars.js module, got:\n%s", output)
+	}
+
+	var sidebars map[string][]*docusaurusItem
+	if err := json.Unmarshal([]byte(strings.TrimSuffix(output[start+len(prefix):], suffix)), &sidebars); err != nil {
+		t.Fatalf("sidebar is not valid JSON: %v", err)
+	}
+
+	docs := sidebars["docs"]
+	if len(docs) != 3 {
+		t.Fatalf("expected 3 top-level items, got %d", len(docs))
+	}
+
+	guides := docs[0]
+	if guides.Type != "category" || guides.Label != "guides" || guides.Link == nil || guides.Link.ID != "guides/README" {
+		t.Errorf("expected guides category linked to its index, got %+v", guides)
+	}
+	if setup := guides.Items[1]; setup.Type != "doc" || setup.ID != "guides/setup" || setup.Label != "Setup: the basics" {
+		t.Errorf("expected setup doc labelled with its title, got %+v", setup)
+	}
+	if readme := docs[2]; readme.ID != "README" || readme.Label != "Welcome" {
+		t.Errorf("expected root README doc, got %+v", readme)
+	}
+}
+
+func TestDocusaurusID(t *testing.T) {
+	tests := []struct {
+		path     string
+		id       string
+		expected string
+	}{
+		{"guides/setup.md", "", "guides/setup"},
+		{"tut/02-setup.md", "", "tut/setup"},
+		{"01-basics/10_intro.md", "", "basics/intro"},
+		{"10-deploy.md", "deploying", "deploying"},
+		{"01-ops/10-deploy.md", "deploying", "ops/deploying"},
+		{"2021-01-31-release.md", "", "2021-01-31-release"},
+		{"v1.2-notes.md", "", "v1.2-notes"},
+		{"42.md", "", "42"},
+	}
+
+	for _, tt := range tests {
+		node := NewNode(path.Base(tt.path), tt.path, false)
+		node.ID = tt.id
+		if got := docusaurusID(node); got != tt.expected {
+			t.Errorf("docusaurusID(%q, id %q): expected %q, got %q", tt.path, tt.id, tt.expected, got)
+		}
+	}
+}
+
+func TestGeneratorMdBook(t *testing.T) {
+	tree := navTestTree()
+	tree.LimitChildren(2)
+
+	output := NewGenerator(GeneratorConfig{Title: "Summary", Format: FormatMdBook}).Generate(tree)
+
+	expected := `# Summary
+
+- [guides](guides/README.md)
+    - [faq](guides/faq.md)
+    - [Setup: the basics](guides/setup.md)
+- [reference]()
+    - [API \[v2\]](reference/api.md)
+`
+	if output != expected {
+		t.Errorf("unexpected SUMMARY.md:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := map[string]string{
+		"Getting Started": "Getting Started",
+		"Setup: basics":   `"Setup: basics"`,
+		"- dash":          `"- dash"`,
+		"yes":             `"yes"`,
+		`say "hi"`:        `"say \"hi\""`,
+	}
+	for input, expected := range tests {
+		if result := yamlString(input); result != expected {
+			t.Errorf("yamlString(%q) = %s, want %s", input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package toc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RecentlyUpdated returns up to n files of the tree, most recently updated
+// first. Promoted index files and entries hidden behind "… and N more"
+// count too. Deleted files and files without a known update time are left
+// out, and files listed more than once (as in grouped trees) are returned
+// once.
+func RecentlyUpdated(tree *Tree, n int) []*Node {
+	if n <= 0 {
+		return nil
+	}
+
+	var files []*Node
+	seen := make(map[string]bool)
+	eachFile(tree.Root.Children, func(node *Node) {
+		if node.Change == ChangeDeleted || node.Updated().IsZero() || seen[node.Path] {
+			return
+		}
+		seen[node.Path] = true
+		files = append(files, node)
+	})
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Updated().After(files[j].Updated())
+	})
+	if len(files) > n {
+		files = files[:n]
+	}
+	return files
+}
+
+// eachFile calls fn for every file below nodes, including promoted index
+// files and the entries an overflow node stands in for.
+func eachFile(nodes []*Node, fn func(node *Node)) {
+	for _, node := range nodes {
+		switch {
+		case node.Overflow > 0:
+			eachFile(node.Omitted, fn)
+		case node.IsDir:
+			if node.Index != nil {
+				fn(node.Index)
+			}
+			eachFile(node.Children, fn)
+		default:
+			fn(node)
+		}
+	}
+}
+
+// recentSection renders a "Recently Updated" markdown section listing the
+// configured number of files with their update date and last author, or
+// "" when disabled.
+func (g *Generator) recentSection(tree *Tree) string {
+	files := RecentlyUpdated(tree, g.config.Recent)
+	if len(files) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n## Recently Updated\n\n")
+	for _, file := range files {
+		fmt.Fprintf(&sb, "- [%s](%s) — %s", file.Path, file.Path, file.Updated().Format("2006-01-02"))
+		if file.LastAuthor != "" {
+			fmt.Fprintf(&sb, " by %s", file.LastAuthor)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,129 @@
+package toc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newHistoryTree(now time.Time) *Tree {
+	tree := NewTree("project")
+	readme := tree.AddFile("README.md")
+	readme.ModTime = now
+	readme.LastCommit, readme.LastAuthor, readme.Commits = now.AddDate(0, -6, 0), "Alice", 4
+	guide := tree.AddFile("docs/guide.md")
+	guide.ModTime = now
+	guide.LastCommit, guide.LastAuthor, guide.Commits = now.AddDate(0, 0, -2), "Bob", 1
+	// Uncommitted files fall back to their modification time
+	tree.AddFile("docs/notes.md").ModTime = now.AddDate(0, 0, -1)
+	tree.AddFile("docs/unknown.md")
+	tree.Sort()
+	return tree
+}
+
+func TestRecentlyUpdated(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tree := newHistoryTree(now)
+
+	var paths []string
+	for _, node := range RecentlyUpdated(tree, 5) {
+		paths = append(paths, node.Path)
+	}
+	if result := strings.Join(paths, " "); result != "docs/notes.md docs/guide.md README.md" {
+		t.Errorf("unexpected recent files %q", result)
+	}
+
+	if files := RecentlyUpdated(tree, 1); len(files) != 1 || files[0].Path != "docs/notes.md" {
+		t.Errorf("expected only the most recent file, got %v", files)
+	}
+	if files := RecentlyUpdated(tree, 0); files != nil {
+		t.Errorf("expected no files when disabled, got %v", files)
+	}
+
+	// Files listed in several groups are returned once
+	grouped := GroupBy(tree, func(node *Node) []string { return []string{"a", "b"} })
+	if files := RecentlyUpdated(grouped, 10); len(files) != 3 {
+		t.Errorf("expected grouped copies to be deduplicated, got %d files", len(files))
+	}
+}
+
+func TestRecentlyUpdatedIndexAndOverflow(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tree := newHistoryTree(now)
+	tree.AddFile("docs/README.md").LastCommit = now
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+	tree.LimitChildren(1)
+
+	var paths []string
+	for _, node := range RecentlyUpdated(tree, 5) {
+		paths = append(paths, node.Path)
+	}
+	expected := "docs/README.md docs/notes.md docs/guide.md README.md"
+	if result := strings.Join(paths, " "); result != expected {
+		t.Errorf("expected promoted and hidden files %q, got %q", expected, result)
+	}
+}
+
+func TestRecentlyUpdatedSkipsDeleted(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tree := newHistoryTree(now)
+	removed := tree.AddFile("docs/removed.md")
+	removed.LastCommit, removed.Change = now, ChangeDeleted
+
+	for _, node := range RecentlyUpdated(tree, 5) {
+		if node.Path == "docs/removed.md" {
+			t.Error("deleted files should not be listed as recently updated")
+		}
+	}
+}
+
+func TestGeneratorRecentSection(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tree := newHistoryTree(now)
+
+	section := "\n## Recently Updated\n\n" +
+		"- [docs/notes.md](docs/notes.md) — 2025-05-31\n" +
+		"- [docs/guide.md](docs/guide.md) — 2025-05-30 by Bob\n"
+
+	for _, config := range []GeneratorConfig{{}, {Fancy: true}, {Format: FormatDetails}} {
+		config.Recent = 2
+		result := NewGenerator(config).Generate(tree)
+		if !strings.HasSuffix(result, section) {
+			t.Errorf("format %q fancy %v: expected recent section at the end, got:\n%s", config.Format, config.Fancy, result)
+		}
+	}
+
+	if result := NewGenerator(GeneratorConfig{}).Generate(tree); strings.Contains(result, "Recently Updated") {
+		t.Errorf("recent section should be disabled by default, got:\n%s", result)
+	}
+}
+
+func TestGeneratorJSONHistory(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tree := newHistoryTree(now)
+
+	result := NewGenerator(GeneratorConfig{Format: FormatJSON, Recent: 1}).Generate(tree)
+
+	var doc struct {
+		Recent   []string `json:"recent"`
+		Children []struct {
+			Path       string `json:"path"`
+			LastCommit string `json:"lastCommit"`
+			LastAuthor string `json:"lastAuthor"`
+			Commits    int    `json:"commits"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal([]byte(result), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, result)
+	}
+
+	if len(doc.Recent) != 1 || doc.Recent[0] != "docs/notes.md" {
+		t.Errorf("unexpected recent list %v", doc.Recent)
+	}
+	readme := doc.Children[1]
+	if readme.Path != "README.md" || readme.LastCommit != "2024-12-01T12:00:00Z" || readme.LastAuthor != "Alice" || readme.Commits != 4 {
+		t.Errorf("unexpected README history %+v", readme)
+	}
+}
@@ -0,0 +1,206 @@
+package toc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SortOrder selects how the children of each directory are ordered.
+// Directories always come before files, except for entries listed
+// explicitly in a directory's Order, which come first in that order.
+type SortOrder string
+
+const (
+	SortName    SortOrder = "name"    // Case-insensitive alphabetical (default)
+	SortNatural SortOrder = "natural" // Alphabetical, with numbers compared by value
+	SortWeight  SortOrder = "weight"  // Frontmatter weight ascending, then natural
+	SortModTime SortOrder = "mtime"   // Most recently modified first
+	SortTitle   SortOrder = "title"   // Document title, then natural
+	SortUpdated SortOrder = "updated" // Most recently committed first, falling back to mtime
+)
+
+// SortOrders lists all supported sort orders.
+var SortOrders = []SortOrder{SortName, SortNatural, SortWeight, SortModTime, SortTitle, SortUpdated}
+
+// ParseSortOrder validates a sort order name. An empty name selects SortName.
+func ParseSortOrder(name string) (SortOrder, error) {
+	if name == "" {
+		return SortName, nil
+	}
+	for _, order := range SortOrders {
+		if string(order) == name {
+			return order, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort order %q (expected name, natural, weight, mtime, title or updated)", name)
+}
+
+// SortBy sorts children recursively using the given order.
+func (n *Node) SortBy(order SortOrder) {
+	less := lessFunc(order)
+	explicit := explicitPositions(n.Order)
+
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+
+		// Entries listed in .order come first, in the listed order
+		posA, listedA := explicit[a.Name]
+		posB, listedB := explicit[b.Name]
+		if listedA || listedB {
+			if listedA && listedB {
+				return posA < posB
+			}
+			return listedA
+		}
+
+		// Directories first
+		if a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		return less(a, b)
+	})
+
+	for _, child := range n.Children {
+		child.SortBy(order)
+	}
+}
+
+// SortBy sorts the entire tree using the given order.
+func (t *Tree) SortBy(order SortOrder) {
+	t.Root.SortBy(order)
+}
+
+// explicitPositions maps names listed in an order file to their position.
+// Trailing slashes used to mark directories are ignored.
+func explicitPositions(order []string) map[string]int {
+	positions := make(map[string]int, len(order))
+	for i, name := range order {
+		name = strings.TrimSuffix(name, "/")
+		if _, exists := positions[name]; !exists {
+			positions[name] = i
+		}
+	}
+	return positions
+}
+
+// lessFunc returns the comparison for siblings of the same kind.
+func lessFunc(order SortOrder) func(a, b *Node) bool {
+	switch order {
+	case SortNatural:
+		return func(a, b *Node) bool {
+			return naturalLess(a.Name, b.Name)
+		}
+	case SortWeight:
+		return func(a, b *Node) bool {
+			// Unweighted (0) entries go after weighted ones
+			if a.Weight != b.Weight {
+				if a.Weight == 0 || b.Weight == 0 {
+					return b.Weight == 0
+				}
+				return a.Weight < b.Weight
+			}
+			return naturalLess(a.Name, b.Name)
+		}
+	case SortModTime:
+		return newestFirst(func(n *Node) time.Time { return n.ModTime })
+	case SortUpdated:
+		return newestFirst((*Node).Updated)
+	case SortTitle:
+		return func(a, b *Node) bool {
+			ta, tb := strings.ToLower(a.displayTitle()), strings.ToLower(b.displayTitle())
+			if ta != tb {
+				return naturalLess(ta, tb)
+			}
+			return naturalLess(a.Name, b.Name)
+		}
+	default:
+		return func(a, b *Node) bool {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+	}
+}
+
+// displayTitle returns the node's title, falling back to its name.
+func (n *Node) displayTitle() string {
+	if n.Title != "" {
+		return n.Title
+	}
+	return n.Name
+}
+
+// newestFirst returns a comparison ordering nodes by the time returned by
+// fileTime, most recent first. Directories use their most recent file.
+func newestFirst(fileTime func(n *Node) time.Time) func(a, b *Node) bool {
+	return func(a, b *Node) bool {
+		ta, tb := a.latest(fileTime), b.latest(fileTime)
+		if !ta.Equal(tb) {
+			return ta.After(tb)
+		}
+		return naturalLess(a.Name, b.Name)
+	}
+}
+
+// latest returns fileTime of a file, or for a directory the most recent
+// fileTime of any file below it.
+func (n *Node) latest(fileTime func(n *Node) time.Time) time.Time {
+	if !n.IsDir {
+		return fileTime(n)
+	}
+
+	var latest time.Time
+	for _, child := range n.Children {
+		if t := child.latest(fileTime); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// Updated returns when a file was last changed: its last commit date if
+// known, otherwise its modification time.
+func (n *Node) Updated() time.Time {
+	if !n.LastCommit.IsZero() {
+		return n.LastCommit
+	}
+	return n.ModTime
+}
+
+// naturalLess compares strings case-insensitively, treating runs of digits
+// as numbers so that "2-setup" sorts before "10-deploy".
+func naturalLess(a, b string) bool {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	i, j := 0, 0
+
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			startA, startB := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+
+			numA := strings.TrimLeft(string(ra[startA:i]), "0")
+			numB := strings.TrimLeft(string(rb[startB:j]), "0")
+			if len(numA) != len(numB) {
+				return len(numA) < len(numB)
+			}
+			if numA != numB {
+				return numA < numB
+			}
+			continue
+		}
+
+		if ra[i] != rb[j] {
+			return ra[i] < rb[j]
+		}
+		i++
+		j++
+	}
+
+	return len(ra)-i < len(rb)-j
+}
@@ -0,0 +1,102 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		less bool
+	}{
+		{"2-setup.md", "10-deploy.md", true},
+		{"10-deploy.md", "2-setup.md", false},
+		{"file2", "file10", true},
+		{"Alpha", "beta", true},
+		{"chapter-02", "chapter-2a", true},
+		{"abc", "abcd", true},
+		{"same", "same", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"<"+tt.b, func(t *testing.T) {
+			if result := naturalLess(tt.a, tt.b); result != tt.less {
+				t.Errorf("naturalLess(%q, %q) = %v, expected %v", tt.a, tt.b, result, tt.less)
+			}
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		order    SortOrder
+		expected string
+	}{
+		{"name", SortName, "guides 10-deploy.md 2-setup.md intro.md"},
+		{"natural", SortNatural, "guides 2-setup.md 10-deploy.md intro.md"},
+		{"weight", SortWeight, "guides intro.md 10-deploy.md 2-setup.md"},
+		{"mtime", SortModTime, "guides 2-setup.md intro.md 10-deploy.md"},
+		{"title", SortTitle, "guides 10-deploy.md intro.md 2-setup.md"},
+		{"updated", SortUpdated, "guides intro.md 2-setup.md 10-deploy.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewTree("project")
+			deploy := tree.AddFile("10-deploy.md")
+			deploy.Weight, deploy.Title, deploy.ModTime = 20, "Deploying", now.Add(-2*time.Hour)
+			setup := tree.AddFile("2-setup.md")
+			setup.Title, setup.ModTime = "Setup", now
+			intro := tree.AddFile("intro.md")
+			intro.Weight, intro.Title, intro.ModTime = 10, "Introduction", now.Add(-time.Hour)
+			intro.LastCommit = now.Add(time.Hour)
+			tree.AddFile("guides/a.md")
+
+			tree.SortBy(tt.order)
+
+			if result := childNames(tree.Root); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSortExplicitOrder(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("b.md")
+	tree.AddFile("a.md")
+	tree.AddFile("zeta/x.md")
+	tree.AddFile("alpha/y.md")
+	tree.Root.Order = []string{"b.md", "zeta/", "missing.md"}
+
+	tree.Sort()
+
+	// Listed entries first in listed order, then directories, then files
+	if result := childNames(tree.Root); result != "b.md zeta alpha a.md" {
+		t.Errorf("expected explicit order first, got %q", result)
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	if order, err := ParseSortOrder(""); err != nil || order != SortName {
+		t.Errorf("empty order should default to name, got %q (%v)", order, err)
+	}
+	if _, err := ParseSortOrder("random"); err == nil {
+		t.Error("expected error for unknown sort order")
+	}
+}
+
+// Helper functions
+
+func childNames(node *Node) string {
+	names := make([]string, len(node.Children))
+	for i, child := range node.Children {
+		names[i] = child.Name
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,13 @@
+package toc
+
+// symlinkMarker follows the name of an entry reached through a symbolic
+// link.
+const symlinkMarker = " ↪"
+
+// linkMarker returns symlinkMarker for symlinked nodes and "" otherwise.
+func linkMarker(node *Node) string {
+	if !node.Symlink {
+		return ""
+	}
+	return symlinkMarker
+}
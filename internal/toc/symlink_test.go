@@ -0,0 +1,40 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratorSymlinks(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("docs/guide.md").Symlink = true
+	tree.AddFile("shared/intro.md")
+	tree.Find("shared").Symlink = true
+	tree.Sort()
+
+	tests := []struct {
+		config GeneratorConfig
+		want   []string
+	}{
+		{GeneratorConfig{}, []string{"[guide.md](docs/guide.md) ↪  \n", "shared/ ↪  \n"}},
+		{GeneratorConfig{Fancy: true}, []string{"**shared/** ↪\n", "[guide.md](docs/guide.md) ↪\n"}},
+		{GeneratorConfig{Format: FormatDetails}, []string{"<summary>shared/ ↪</summary>", "- [guide.md](docs/guide.md) ↪\n"}},
+		{GeneratorConfig{Format: FormatTerm, NoColor: true}, []string{"shared/ ↪\n"}},
+		{GeneratorConfig{Format: FormatJSON}, []string{`"symlink": true`}},
+		{GeneratorConfig{Format: FormatHTML}, []string{`<span class="symlink" title="symbolic link">↪</span>`}},
+	}
+
+	for _, tt := range tests {
+		result := NewGenerator(tt.config).Generate(tree)
+		for _, want := range tt.want {
+			if !strings.Contains(result, want) {
+				t.Errorf("format %q: expected %q in output, got:\n%s", tt.config.Format, want, result)
+			}
+		}
+	}
+
+	// Regular entries have no marker
+	if result := NewGenerator(GeneratorConfig{}).Generate(tree); strings.Contains(result, "intro.md) ↪") {
+		t.Errorf("expected no marker on regular files, got:\n%s", result)
+	}
+}
@@ -0,0 +1,198 @@
+package toc
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateData is the data model passed to user-defined templates.
+type TemplateData struct {
+	Title  string          // Title for the ToC
+	Root   *TemplateNode   // Root directory; its Children are the top-level entries
+	Nodes  []*TemplateNode // Top-level entries (same as Root.Children)
+	Stats  Stats           // File, directory and depth counts
+	Recent []*TemplateNode // Recently updated files, if enabled
+}
+
+// TemplateNode is a tree node as seen by templates. Summaries and tags are
+// filled in when --summary and --tags are enabled.
+type TemplateNode struct {
+	Name       string          // File or directory name
+	Path       string          // Relative path from root
+	Link       string          // Target to link to: the file, a directory's index, or "" for plain directories
+	IsDir      bool            // True for directories
+	IsOverflow bool            // True for "… and N more" nodes
+	Hidden     int             // Number of entries an overflow node stands for
+	Depth      int             // Nesting level (0 for top-level entries)
+	IsLast     bool            // True if this is the last entry in its directory
+	Prefix     string          // ASCII tree prefix, e.g. "│   ├── "
+	Title      string          // Document title from frontmatter or first H1
+	Summary    string          // Summary, if enabled
+	Tags       []string        // Frontmatter tags, if enabled
+	Keywords   []string        // Extracted keywords, if enabled
+	Weight     int             // Sort weight from frontmatter
+	Status     string          // Document status from frontmatter, e.g. draft
+	Badges     []string        // Status and staleness badges, if enabled
+	ModTime    time.Time       // Last modification time (files)
+	LastCommit time.Time       // Date of the last git commit, if git metadata is enabled
+	LastAuthor string          // Author of the last git commit
+	Commits    int             // Number of git commits touching the file
+	Change     string          // Git change with --changed-since: added, modified or deleted
+	Symlink    bool            // True if the entry was reached through a symbolic link
+	Index      *TemplateNode   // Promoted index file of a directory
+	Children   []*TemplateNode // Child entries of a directory
+}
+
+// TemplateFuncs are the helper functions available to templates.
+var TemplateFuncs = template.FuncMap{
+	// flatten lists nodes and all their descendants depth-first, so simple
+	// templates can range over the whole tree without recursion
+	"flatten": flattenTemplateNodes,
+	// relative rewrites a root-relative path to be relative to dir, for
+	// output files written below the root: {{relative "docs" .Link}}
+	"relative": relativePath,
+	// slug returns a GitHub-style anchor slug for a path
+	"slug": generateSlug,
+	// indent returns two spaces per level: {{indent .Depth}}
+	"indent": func(depth int) string { return strings.Repeat("  ", depth) },
+	// truncate shortens text to n characters, adding "..."
+	"truncate": func(n int, text string) string { return truncateText(text, n) },
+	// trimExt removes the file extension from a name or path
+	"trimExt": func(name string) string { return strings.TrimSuffix(name, path.Ext(name)) },
+	"join":    func(sep string, items []string) string { return strings.Join(items, sep) },
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"repeat":  func(n int, s string) string { return strings.Repeat(s, max(n, 0)) },
+}
+
+// ParseTemplateFile parses a user-defined template with TemplateFuncs.
+func ParseTemplateFile(filename string) (*template.Template, error) {
+	return template.New(filepath.Base(filename)).Funcs(TemplateFuncs).ParseFiles(filename)
+}
+
+// GenerateTemplate executes a user-defined template over the tree.
+func (g *Generator) GenerateTemplate(tree *Tree, tmpl *template.Template) (string, error) {
+	root := &TemplateNode{Name: tree.Root.Name, Path: tree.Root.Path, IsDir: true}
+	root.Children = g.templateNodes(tree.Root.Children, 0, nil)
+
+	data := TemplateData{
+		Title: g.config.Title,
+		Root:  root,
+		Nodes: root.Children,
+		Stats: GetStats(tree),
+	}
+	for _, file := range RecentlyUpdated(tree, g.config.Recent) {
+		data.Recent = append(data.Recent, g.templateNode(file))
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// templateNodes converts nodes and their descendants for templates.
+// isLastAtLevel tracks the ancestors for building tree prefixes.
+func (g *Generator) templateNodes(nodes []*Node, depth int, isLastAtLevel []bool) []*TemplateNode {
+	result := make([]*TemplateNode, 0, len(nodes))
+
+	for i, node := range nodes {
+		isLast := i == len(nodes)-1
+		tn := g.templateNode(node)
+		tn.Depth = depth
+		tn.IsLast = isLast
+		tn.Prefix = buildPrefix(isLastAtLevel, isLast)
+
+		if node.IsDir {
+			childLevels := append(append([]bool{}, isLastAtLevel...), isLast)
+			tn.Children = g.templateNodes(node.Children, depth+1, childLevels)
+			if node.Index != nil {
+				tn.Index = g.templateNode(node.Index)
+			}
+		}
+
+		result = append(result, tn)
+	}
+
+	return result
+}
+
+// templateNode converts a single node, without its children.
+func (g *Generator) templateNode(node *Node) *TemplateNode {
+	tn := &TemplateNode{
+		Name:       node.Name,
+		Path:       node.Path,
+		Link:       node.link(),
+		IsDir:      node.IsDir,
+		IsOverflow: node.Overflow > 0,
+		Hidden:     node.Overflow,
+		Title:      node.Title,
+		Weight:     node.Weight,
+		ModTime:    node.ModTime,
+		Change:     node.Change,
+		Symlink:    node.Symlink,
+	}
+
+	if doc := node.document(); doc != nil {
+		if node.IsDir {
+			tn.Title = doc.Title
+		}
+		if g.config.IncludeSummary {
+			tn.Summary = g.summaryFor(doc)
+		}
+		if g.config.IncludeTags {
+			tn.Tags = doc.Tags
+			tn.Keywords = doc.Keywords
+		}
+		tn.Status = doc.Status
+		tn.LastCommit = doc.LastCommit
+		tn.LastAuthor = doc.LastAuthor
+		tn.Commits = doc.Commits
+		tn.Badges = g.badgesFor(doc)
+	}
+
+	return tn
+}
+
+// flattenTemplateNodes lists nodes and their descendants depth-first.
+func flattenTemplateNodes(nodes []*TemplateNode) []*TemplateNode {
+	var result []*TemplateNode
+	for _, node := range nodes {
+		result = append(result, node)
+		result = append(result, flattenTemplateNodes(node.Children)...)
+	}
+	return result
+}
+
+// relativePath rewrites a slash-separated path relative to the root so it
+// is relative to dir instead. Empty paths stay empty.
+func relativePath(dir, target string) string {
+	if target == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+	if strings.HasSuffix(target, "/") {
+		rel += "/"
+	}
+	return filepath.ToSlash(rel)
+}
+
+// truncateText shortens text to at most maxChars characters, ending with
+// "..." when it was cut.
+func truncateText(text string, maxChars int) string {
+	runes := []rune(text)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 3 {
+		return string(runes[:maxChars])
+	}
+	return strings.TrimSpace(string(runes[:maxChars-3])) + "..."
+}
@@ -0,0 +1,105 @@
+package toc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestGenerateTemplate(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md").Title = "Welcome"
+	tree.AddFile("docs/README.md").Title = "Docs"
+	tree.AddFile("docs/guide.md").Tags = []string{"intro"}
+	tree.Sort()
+	tree.PromoteIndex(DefaultIndexNames)
+
+	gen := NewGenerator(GeneratorConfig{
+		Title:          "Index",
+		IncludeSummary: true,
+		IncludeTags:    true,
+		Summaries:      map[string]string{"docs/guide.md": "Getting started guide for new users."},
+	})
+
+	tmpl := template.Must(template.New("test").Funcs(TemplateFuncs).Parse(
+		`# {{.Title}} ({{.Stats.TotalFiles}} files)
+{{range flatten .Nodes}}{{indent .Depth}}- {{if .IsDir}}{{.Name}}/ -> {{relative "docs" .Link}} ({{.Title}}){{else}}[{{trimExt .Name}}]({{.Link}}) #{{slug .Path}}{{end}}{{with .Summary}} {{truncate 15 .}}{{end}}{{with .Tags}} [{{join ", " .}}]{{end}}
+{{end}}`))
+
+	output, err := gen.GenerateTemplate(tree, tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Index (3 files)
+- docs/ -> README.md (Docs)
+  - [guide](docs/guide.md) #docs-guide Getting star... [intro]
+- [README](README.md) #readme
+`
+	if output != expected {
+		t.Errorf("unexpected template output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGenerateTemplatePrefixes(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("a/b.md")
+	tree.AddFile("c.md")
+	tree.Sort()
+
+	tmpl := template.Must(template.New("tree").Funcs(TemplateFuncs).Parse(
+		`{{define "node"}}{{.Prefix}}{{.Name}}
+{{range .Children}}{{template "node" .}}{{end}}{{end}}{{range .Nodes}}{{template "node" .}}{{end}}`))
+
+	output, err := NewGenerator(GeneratorConfig{}).GenerateTemplate(tree, tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "├── a\n│   └── b.md\n└── c.md\n"
+	if output != expected {
+		t.Errorf("unexpected recursive template output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestGenerateTemplateError(t *testing.T) {
+	tmpl := template.Must(template.New("bad").Parse(`{{.Missing}}`))
+
+	if _, err := NewGenerator(GeneratorConfig{}).GenerateTemplate(NewTree("project"), tmpl); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestParseTemplateFile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "go-toc-template-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "index.tmpl")
+	if err := os.WriteFile(path, []byte(`{{upper .Title}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ParseTemplateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := NewGenerator(GeneratorConfig{Title: "docs"}).GenerateTemplate(NewTree("project"), tmpl)
+	if err != nil || output != "DOCS" {
+		t.Errorf("expected DOCS, got %q (%v)", output, err)
+	}
+
+	if _, err := ParseTemplateFile(filepath.Join(tmpDir, "missing.tmpl")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if err := os.WriteFile(path, []byte(`{{if}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseTemplateFile(path); err == nil || !strings.Contains(err.Error(), "index.tmpl") {
+		t.Errorf("expected a parse error naming the file, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package toc
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI escape sequences used by the terminal format.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiDir   = "\x1b[1;34m"
+	ansiTags  = "\x1b[36m"
+	ansiBadge = "\x1b[33m"
+)
+
+// changeColors are the ANSI colors of change markers in the terminal format.
+var changeColors = map[string]string{
+	ChangeAdded:    "\x1b[32m",
+	ChangeModified: "\x1b[33m",
+	ChangeDeleted:  "\x1b[31m",
+}
+
+// generateTerm creates a tree(1)-style view for reading in a terminal:
+// plain box-drawing prefixes, ANSI colors (unless NoColor), OSC 8
+// hyperlinks to files (when RootPath is set) and summaries truncated to
+// fit within Width columns (when known).
+func (g *Generator) generateTerm(tree *Tree) string {
+	var sb strings.Builder
+
+	sb.WriteString(g.style(ansiBold, g.config.Title))
+	sb.WriteString("\n")
+
+	isLastAtLevel := make([]bool, 0)
+
+	tree.Walk(func(node *Node, depth int, isLast bool) {
+		if len(isLastAtLevel) > depth {
+			isLastAtLevel = isLastAtLevel[:depth]
+		}
+
+		sb.WriteString(buildPrefix(isLastAtLevel, isLast))
+
+		name := node.Name
+		switch {
+		case node.IsDir:
+			name = g.style(ansiDir, name+"/")
+		case node.Overflow > 0, node.deleted():
+			name = g.style(ansiDim, name)
+		}
+		sb.WriteString(g.hyperlink(node.link(), name))
+		sb.WriteString(linkMarker(node))
+		if node.Change != "" {
+			sb.WriteString(" ")
+			sb.WriteString(g.style(changeColors[node.Change], "("+node.Change+")"))
+		}
+		for _, badge := range g.badgesFor(node.document()) {
+			sb.WriteString(" ")
+			sb.WriteString(g.style(ansiBadge, "["+badge+"]"))
+		}
+		sb.WriteString("\n")
+
+		if doc := node.document(); doc != nil {
+			notePrefix := buildContinuationPrefix(isLastAtLevel, isLast)
+
+			if g.config.IncludeSummary {
+				if summary := g.summaryFor(doc); summary != "" {
+					sb.WriteString(notePrefix)
+					sb.WriteString(g.style(ansiDim, g.fitWidth(summary, notePrefix)))
+					sb.WriteString("\n")
+				}
+			}
+			if g.config.IncludeTags {
+				if tags := tagsFor(doc); len(tags) > 0 {
+					sb.WriteString(notePrefix)
+					sb.WriteString(g.style(ansiTags, g.fitWidth("#"+strings.Join(tags, " #"), notePrefix)))
+					sb.WriteString("\n")
+				}
+			}
+		}
+
+		if node.IsDir {
+			isLastAtLevel = append(isLastAtLevel, isLast)
+		}
+	})
+
+	stats := GetStats(tree)
+	sb.WriteString("\n")
+	sb.WriteString(g.style(ansiDim, fmt.Sprintf("%s, %s",
+		plural(stats.TotalDirectories, "directory", "directories"),
+		plural(stats.TotalFiles, "file", "files"))))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// plural formats a count with the singular or plural form of a noun.
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+// style wraps text in an ANSI style unless colors are disabled.
+func (g *Generator) style(code, text string) string {
+	if g.config.NoColor {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to a path below the root.
+// Terminals without OSC 8 support simply show the text.
+func (g *Generator) hyperlink(relPath, text string) string {
+	if g.config.RootPath == "" || relPath == "" {
+		return text
+	}
+
+	target := url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(g.config.RootPath, relPath))}
+	return "\x1b]8;;" + target.String() + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// fitWidth truncates text with an ellipsis so that prefix followed by text
+// fits within the configured width. Text is unchanged if the width is unknown.
+func (g *Generator) fitWidth(text, prefix string) string {
+	if g.config.Width <= 0 {
+		return text
+	}
+
+	available := g.config.Width - utf8.RuneCountInString(prefix)
+	if available < 1 {
+		available = 1
+	}
+	runes := []rune(text)
+	if len(runes) <= available {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:available-1])) + "…"
+}
@@ -0,0 +1,89 @@
+package toc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratorTerm(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("README.md")
+	tree.AddFile("docs/guide.md")
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{
+		Format:         FormatTerm,
+		NoColor:        true,
+		IncludeSummary: true,
+		Summaries:      map[string]string{"docs/guide.md": "Getting started guide for new users."},
+		Width:          30,
+	})
+	output := gen.Generate(tree)
+
+	expected := `Table of Contents
+├── docs/
+│   └── guide.md
+│       Getting started guide…
+└── README.md
+
+1 directory, 2 files
+`
+	if output != expected {
+		t.Errorf("unexpected term output:\n%s\nwant:\n%s", output, expected)
+	}
+	if strings.Contains(output, "&nbsp;") || strings.Contains(output, "](") {
+		t.Error("term output should contain no markdown")
+	}
+}
+
+func TestGeneratorTermColorsAndLinks(t *testing.T) {
+	tree := NewTree("project")
+	tree.AddFile("my docs/guide.md").Tags = []string{"intro"}
+	tree.Sort()
+
+	gen := NewGenerator(GeneratorConfig{
+		Format:      FormatTerm,
+		IncludeTags: true,
+		RootPath:    "/home/user/project",
+	})
+	output := gen.Generate(tree)
+
+	for _, want := range []string{
+		ansiBold + "Table of Contents" + ansiReset,
+		ansiDir + "my docs/" + ansiReset,
+		"\x1b]8;;file:///home/user/project/my%20docs/guide.md\x1b\\guide.md\x1b]8;;\x1b\\",
+		ansiTags + "#intro" + ansiReset,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in term output, got:\n%q", want, output)
+		}
+	}
+
+	// Directories without an index have nothing to link to
+	if strings.Contains(output, "\x1b]8;;file:///home/user/project/my%20docs\x1b") {
+		t.Error("plain directories should not be hyperlinked")
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	gen := NewGenerator(GeneratorConfig{Width: 10})
+
+	tests := []struct {
+		text, prefix, expected string
+	}{
+		{"short", "", "short"},
+		{"exactly 10", "", "exactly 10"},
+		{"much longer text", "", "much long…"},
+		{"much longer text", "│   ", "much…"},
+		{"text", "a very long prefix", "…"},
+	}
+	for _, tt := range tests {
+		if result := gen.fitWidth(tt.text, tt.prefix); result != tt.expected {
+			t.Errorf("fitWidth(%q, %q) = %q, want %q", tt.text, tt.prefix, result, tt.expected)
+		}
+	}
+
+	if result := NewGenerator(GeneratorConfig{}).fitWidth("much longer text", ""); result != "much longer text" {
+		t.Errorf("unknown width should not truncate, got %q", result)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Node represents a file or directory in the tree structure.
@@ -11,7 +12,24 @@ type Node struct {
 	Name       string           // File or directory name
 	Path       string           // Relative path from root
 	IsDir      bool             // True if this is a directory
+	Virtual    bool             // True for directories that do not exist on disk (e.g. tag groups)
 	Summary    string           // First paragraph summary (for markdown files)
+	Title      string           // Document title from frontmatter or first H1
+	ID         string           // Document ID from frontmatter, used by site generators
+	Tags       []string         // Tags declared in frontmatter
+	Keywords   []string         // Keywords extracted from the content
+	Weight     int              // Sort weight from frontmatter (0 = unweighted)
+	Status     string           // Document status from frontmatter, e.g. draft or deprecated
+	ModTime    time.Time        // Last modification time (for files)
+	LastCommit time.Time        // Date of the last git commit touching the file
+	LastAuthor string           // Author of the last git commit touching the file
+	Commits    int              // Number of git commits touching the file
+	Change     string           // Git change since a revision: added, modified or deleted
+	Symlink    bool             // True if the entry was reached through a symbolic link
+	Order      []string         // Explicit child order from a .order file (for directories)
+	Index      *Node            // Promoted README/index file representing this directory
+	Overflow   int              // Number of hidden entries (for "… and N more" nodes)
+	Omitted    []*Node          // The hidden entries themselves (for "… and N more" nodes)
 	Children   []*Node          // Child nodes (for directories)
 	childIndex map[string]*Node // Fast lookup of children by name
 }
@@ -35,21 +53,17 @@ func (n *Node) AddChild(child *Node) *Node {
 }
 
 // Sort sorts children recursively. Directories come first, then files.
-// Within each group, items are sorted alphabetically.
+// Within each group, items are sorted alphabetically. Entries listed in
+// the node's Order come before all others.
 func (n *Node) Sort() {
-	sort.Slice(n.Children, func(i, j int) bool {
-		// Directories first
-		if n.Children[i].IsDir != n.Children[j].IsDir {
-			return n.Children[i].IsDir
-		}
-		// Then alphabetically (case-insensitive)
-		return strings.ToLower(n.Children[i].Name) < strings.ToLower(n.Children[j].Name)
-	})
+	n.SortBy(SortName)
+}
 
-	// Sort children recursively
-	for _, child := range n.Children {
-		child.Sort()
-	}
+// sortByName sorts nodes alphabetically (case-insensitive).
+func sortByName(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
 }
 
 // FindOrCreatePath finds or creates the path in the tree, returning the final node.
@@ -84,6 +98,27 @@ func (n *Node) FindOrCreatePath(relPath string, isDir bool) *Node {
 	return current
 }
 
+// Find returns the node at relPath, or nil if it does not exist.
+func (n *Node) Find(relPath string) *Node {
+	if relPath == "" || relPath == "." {
+		return n
+	}
+
+	current := n
+	for _, part := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if part == "" {
+			continue
+		}
+		child, exists := current.childIndex[part]
+		if !exists {
+			return nil
+		}
+		current = child
+	}
+
+	return current
+}
+
 // Tree represents the complete file tree structure.
 type Tree struct {
 	Root *Node
@@ -106,6 +141,11 @@ func (t *Tree) AddDirectory(relPath string) *Node {
 	return t.Root.FindOrCreatePath(relPath, true)
 }
 
+// Find returns the node at the specified relative path, or nil.
+func (t *Tree) Find(relPath string) *Node {
+	return t.Root.Find(relPath)
+}
+
 // Sort sorts the entire tree.
 func (t *Tree) Sort() {
 	t.Root.Sort()
@@ -115,3 +115,21 @@ func TestTreeWalk(t *testing.T) {
 		t.Errorf("expected 4 nodes visited, got %d: %v", len(visited), visited)
 	}
 }
+
+func TestTreeFind(t *testing.T) {
+	tree := NewTree("project")
+	guide := tree.AddFile("docs/guide.md")
+
+	if tree.Find("docs/guide.md") != guide {
+		t.Error("Find should return the file node")
+	}
+	if node := tree.Find("docs"); node == nil || !node.IsDir {
+		t.Error("Find should return directory nodes")
+	}
+	if tree.Find(".") != tree.Root {
+		t.Error("Find(\".\") should return the root")
+	}
+	if tree.Find("docs/missing.md") != nil {
+		t.Error("Find should return nil for missing paths")
+	}
+}
@@ -15,6 +15,7 @@ type Job struct {
 type Result struct {
 	FilePath string
 	Summary  string
+	Data     any // Job-specific output beyond the summary
 	Error    error
 }
 